@@ -0,0 +1,113 @@
+// Command genmodels regenerates internal/models/data.go's Models map from
+// a registry (internal/registry's embedded default by default, or
+// whatever -registry points at). It does not itself read
+// cmd/updater/registry.yaml, the file ops edit to track a new model — run
+// it with -registry=../updater/registry.yaml (or keep the embedded
+// default_registry.yaml a mirror of that file, as today) to pick those
+// changes up; TestDefaultRegistryMatchesUpdaterRegistry fails the build
+// if the two fall out of sync.
+//
+// The output stays a plain `var Models = map[string]Model{...}` literal
+// (not a runtime-computed value) because internal/datapatch's AST editor
+// rewrites that literal directly when a model is deprecated; regenerating
+// here is how new models and metadata edits flow the other direction.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strconv"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+var (
+	registryPath = flag.String("registry", "", "registry YAML/JSON file to read (default: the embedded default registry)")
+	outPath      = flag.String("out", "internal/models/data.go", "file to write")
+)
+
+func main() {
+	flag.Parse()
+
+	reg, err := loadRegistry()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "genmodels: %v\n", err)
+		os.Exit(1)
+	}
+
+	src, err := render(reg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "genmodels: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "genmodels: write %s: %v\n", *outPath, err)
+		os.Exit(1)
+	}
+}
+
+func loadRegistry() (*registry.Registry, error) {
+	if *registryPath == "" {
+		return registry.Default()
+	}
+	return registry.Load(*registryPath)
+}
+
+// render produces the formatted contents of data.go from reg, with
+// providers and their models in a stable, deterministic order.
+func render(reg *registry.Registry) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`package models
+
+// Model describes a tracked model ID and the metadata the cheatsheet exposes
+// to MCP clients and the updater's deprecation workflow.
+type Model struct {
+	Provider      string
+	Family        string
+	Modality      string
+	ContextWindow int
+	Status        string // "current", "legacy", or "deprecated"
+	DeprecatedOn  string ` + "`json:\",omitempty\"`" + `
+	Replacement   string ` + "`json:\",omitempty\"`" + `
+}
+
+// Models is the canonical registry of every model ID the cheatsheet tracks,
+// keyed by model ID. Generated from the registry by cmd/genmodels — see
+// that package's doc comment before editing this file by hand.
+var Models = map[string]Model{
+`)
+
+	for _, provider := range reg.ProviderOrder() {
+		for _, m := range reg.Providers[provider].Models {
+			family := m.Family
+			if family == "" {
+				family = m.ID
+			}
+			fmt.Fprintf(&buf, "\t%s: {Provider: %s, Family: %s, Modality: %s, ContextWindow: %d, Status: %s",
+				strconv.Quote(m.ID), strconv.Quote(provider), strconv.Quote(family),
+				strconv.Quote(orDefault(m.Modality, "text")), m.ContextWindow, strconv.Quote(orDefault(m.Status, "current")))
+			if m.DeprecatedOn != "" {
+				fmt.Fprintf(&buf, ", DeprecatedOn: %s", strconv.Quote(m.DeprecatedOn))
+			}
+			if m.Replacement != "" {
+				fmt.Fprintf(&buf, ", Replacement: %s", strconv.Quote(m.Replacement))
+			}
+			buf.WriteString("},\n")
+		}
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+func orDefault(s, def string) string {
+	if strings.TrimSpace(s) == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-server/internal/registry"
+)
+
+func TestRender_ProducesModelsLiteral(t *testing.T) {
+	reg := &registry.Registry{Providers: map[string]registry.Provider{
+		"OpenAI": {Models: []registry.ModelEntry{
+			{ID: "gpt-5", Family: "gpt-5", Modality: "text", ContextWindow: 128000, Status: "current"},
+			{ID: "gpt-4", Status: "deprecated", DeprecatedOn: "2026-01-01", Replacement: "gpt-5"},
+		}},
+	}}
+
+	out, err := render(reg)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	src := string(out)
+
+	if !strings.Contains(src, `"gpt-5": {Provider: "OpenAI", Family: "gpt-5", Modality: "text", ContextWindow: 128000, Status: "current"}`) {
+		t.Errorf("expected a fully-populated entry for gpt-5:\n%s", src)
+	}
+	if !strings.Contains(src, `Family: "gpt-4"`) {
+		t.Errorf("expected Family to default to the model ID when unset:\n%s", src)
+	}
+	if !strings.Contains(src, `DeprecatedOn: "2026-01-01", Replacement: "gpt-5"`) {
+		t.Errorf("expected deprecation fields to carry through:\n%s", src)
+	}
+}
+
+// TestDefaultRegistryMatchesUpdaterRegistry guards the one promise
+// genmodels' doc comment makes: that data.go never drifts from what the
+// updater tracks. genmodels reads internal/registry's embedded default,
+// while cmd/updater's live ops workflow edits cmd/updater/registry.yaml
+// (REGISTRY_CONFIG/--config) — nothing else ties those two files
+// together, so this fails loudly the moment someone edits one without
+// the other.
+func TestDefaultRegistryMatchesUpdaterRegistry(t *testing.T) {
+	embedded, err := registry.Default()
+	if err != nil {
+		t.Fatalf("registry.Default: %v", err)
+	}
+	updater, err := registry.Load("../updater/registry.yaml")
+	if err != nil {
+		t.Fatalf("registry.Load(cmd/updater/registry.yaml): %v", err)
+	}
+	if !reflect.DeepEqual(embedded.Providers, updater.Providers) {
+		t.Errorf("internal/registry/default_registry.yaml and cmd/updater/registry.yaml have diverged — " +
+			"keep them in sync, or data.go will silently fall out of sync with what the updater tracks")
+	}
+}
+
+func TestOrDefault(t *testing.T) {
+	if orDefault("", "text") != "text" {
+		t.Error("expected orDefault to substitute the default for an empty string")
+	}
+	if orDefault("multimodal", "text") != "multimodal" {
+		t.Error("expected orDefault to keep a non-empty value")
+	}
+}
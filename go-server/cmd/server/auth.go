@@ -0,0 +1,89 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// AuthConfig configures the optional auth middleware. Both checks are
+// independently enableable: an empty Tokens list disables the bearer-token
+// check, and a nil/empty Allowlist disables the IP check.
+type AuthConfig struct {
+	// Tokens is the set of accepted bearer tokens. Rotate by listing both
+	// the old and new secret until every client has switched over.
+	Tokens []string
+	// Allowlist restricts access to these CIDR ranges, evaluated against
+	// the real client IP (see realIPMiddleware).
+	Allowlist []*net.IPNet
+}
+
+// authConfigFromEnv builds an AuthConfig from AUTH_BEARER_TOKENS
+// (comma-separated) and AUTH_IP_ALLOWLIST (comma-separated CIDRs).
+func authConfigFromEnv() AuthConfig {
+	var cfg AuthConfig
+	if raw := os.Getenv("AUTH_BEARER_TOKENS"); raw != "" {
+		for _, tok := range strings.Split(raw, ",") {
+			tok = strings.TrimSpace(tok)
+			if tok != "" {
+				cfg.Tokens = append(cfg.Tokens, tok)
+			}
+		}
+	}
+	cfg.Allowlist = parseTrustedProxies(os.Getenv("AUTH_IP_ALLOWLIST"))
+	return cfg
+}
+
+func (c AuthConfig) tokenRequired() bool { return len(c.Tokens) > 0 }
+func (c AuthConfig) ipRestricted() bool  { return len(c.Allowlist) > 0 }
+
+// authMiddleware enforces AuthConfig on every request except /health, which
+// must stay reachable so container probes keep working, and CORS preflight
+// OPTIONS requests, which browsers send without an Authorization header —
+// corsMiddleware (wired inside this one) is what actually answers them. It
+// returns 401 when the bearer token is missing or wrong, and 403 when the
+// client IP isn't in the allowlist.
+func authMiddleware(cfg AuthConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/health" || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if cfg.tokenRequired() && !hasValidToken(r, cfg.Tokens) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			if cfg.ipRestricted() {
+				ip := net.ParseIP(clientIPFromContext(r.Context()))
+				if ip == nil || !isTrustedProxy(ip, cfg.Allowlist) {
+					http.Error(w, "forbidden", http.StatusForbidden)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hasValidToken(r *http.Request, tokens []string) bool {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	given := strings.TrimPrefix(header, prefix)
+
+	for _, want := range tokens {
+		if subtle.ConstantTimeCompare([]byte(given), []byte(want)) == 1 {
+			return true
+		}
+	}
+	return false
+}
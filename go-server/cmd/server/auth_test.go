@@ -0,0 +1,149 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddleware_UnauthenticatedRequestRejected(t *testing.T) {
+	srv := httptest.NewServer(newTestMuxWithAuth(ServerConfig{}, AuthConfig{Tokens: []string{"secret"}}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/mcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("WWW-Authenticate") != "Bearer" {
+		t.Errorf("expected WWW-Authenticate: Bearer, got %q", resp.Header.Get("WWW-Authenticate"))
+	}
+}
+
+func TestAuthMiddleware_WrongTokenRejected(t *testing.T) {
+	srv := httptest.NewServer(newTestMuxWithAuth(ServerConfig{}, AuthConfig{Tokens: []string{"secret"}}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/mcp", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_CorrectTokenAccepted(t *testing.T) {
+	srv := httptest.NewServer(newTestMuxWithAuth(ServerConfig{}, AuthConfig{Tokens: []string{"secret"}}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/health", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected /health to always be 200, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/sse", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 with correct token, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_AllowlistedIPWithoutToken(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("127.0.0.1/32")
+	srv := httptest.NewServer(newTestMuxWithAuth(ServerConfig{}, AuthConfig{Allowlist: []*net.IPNet{cidr}}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/sse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 for allowlisted IP without a token, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_NonAllowlistedIPRejected(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+	srv := httptest.NewServer(newTestMuxWithAuth(ServerConfig{}, AuthConfig{Allowlist: []*net.IPNet{cidr}}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/sse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for non-allowlisted IP, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_HealthAlwaysReachable(t *testing.T) {
+	srv := httptest.NewServer(newTestMuxWithAuth(ServerConfig{}, AuthConfig{
+		Tokens:    []string{"secret"},
+		Allowlist: mustCIDRs("10.0.0.0/8"),
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected /health 200 regardless of auth config, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_CORSPreflightBypassesAuth(t *testing.T) {
+	srv := httptest.NewServer(newTestMuxWithAuth(ServerConfig{}, AuthConfig{Tokens: []string{"secret"}}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodOptions, srv.URL+"/mcp", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected a CORS preflight to get 204 without a token, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Errorf("expected Access-Control-Allow-Origin echoed back, got %q", got)
+	}
+}
+
+func mustCIDRs(cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
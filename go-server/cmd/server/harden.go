@@ -0,0 +1,137 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ServerConfig holds the tunable limits enforced by hardenedMux. Zero values
+// fall back to sane defaults via newServerConfig.
+type ServerConfig struct {
+	// MaxGlobalSessions caps the number of concurrent SSE sessions across
+	// all clients.
+	MaxGlobalSessions int
+	// MaxSessionsPerIP caps the number of concurrent SSE sessions from a
+	// single source IP.
+	MaxSessionsPerIP int
+	// MaxBodyBytes caps the size of /mcp POST bodies.
+	MaxBodyBytes int64
+	// ReadHeaderTimeout bounds how long a client has to finish sending
+	// request headers, closing off slow-loris style connections.
+	ReadHeaderTimeout time.Duration
+}
+
+const (
+	defaultMaxGlobalSessions        = 256
+	defaultMaxSessionsPerIP         = 8
+	defaultMaxBodyBytes       int64 = 1 << 20 // 1 MiB
+	defaultReadHeaderTimeout        = 10 * time.Second
+)
+
+// newServerConfig fills in defaults for any zero-valued field.
+func newServerConfig(cfg ServerConfig) ServerConfig {
+	if cfg.MaxGlobalSessions <= 0 {
+		cfg.MaxGlobalSessions = defaultMaxGlobalSessions
+	}
+	if cfg.MaxSessionsPerIP <= 0 {
+		cfg.MaxSessionsPerIP = defaultMaxSessionsPerIP
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if cfg.ReadHeaderTimeout <= 0 {
+		cfg.ReadHeaderTimeout = defaultReadHeaderTimeout
+	}
+	return cfg
+}
+
+// sessionLimiter enforces a global cap and a per-IP cap on concurrently
+// active SSE sessions.
+type sessionLimiter struct {
+	cfg ServerConfig
+
+	mu      sync.Mutex
+	global  int
+	perIP   map[string]int
+}
+
+func newSessionLimiter(cfg ServerConfig) *sessionLimiter {
+	return &sessionLimiter{cfg: cfg, perIP: make(map[string]int)}
+}
+
+// acquire tries to reserve a session slot for ip. On success it returns a
+// release func the caller must call when the session ends.
+func (l *sessionLimiter) acquire(ip string) (release func(), ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.global >= l.cfg.MaxGlobalSessions {
+		return nil, false
+	}
+	if l.perIP[ip] >= l.cfg.MaxSessionsPerIP {
+		return nil, false
+	}
+
+	l.global++
+	l.perIP[ip]++
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.global--
+		l.perIP[ip]--
+		if l.perIP[ip] <= 0 {
+			delete(l.perIP, ip)
+		}
+	}, true
+}
+
+// hardenedMux wraps an *http.ServeMux with DoS-resistance: a global and
+// per-IP cap on concurrently active SSE sessions and a size limit on /mcp
+// POST bodies. The read-header timeout against slow-loris connections lives
+// on the *http.Server instead; see withReadHeaderTimeout.
+//
+// ssePaths lets the caller mark which paths are SSE session entry
+// points (today just "/sse"), since those are long-lived connections that
+// need to hold a session slot for their lifetime; everything else is a
+// normal short-lived request.
+func hardenedMux(mux *http.ServeMux, cfg ServerConfig, ssePaths map[string]bool) http.Handler {
+	cfg = newServerConfig(cfg)
+	limiter := newSessionLimiter(cfg)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if r.ContentLength > cfg.MaxBodyBytes {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, cfg.MaxBodyBytes)
+		}
+
+		if ssePaths[r.URL.Path] {
+			ip := clientIPFromContext(r.Context())
+			if ip == "" {
+				ip, _, _ = net.SplitHostPort(r.RemoteAddr)
+			}
+
+			release, ok := limiter.acquire(ip)
+			if !ok {
+				w.Header().Set("Retry-After", "5")
+				http.Error(w, "too many concurrent sessions", http.StatusServiceUnavailable)
+				return
+			}
+			defer release()
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// withReadHeaderTimeout is a small helper for wiring cfg.ReadHeaderTimeout
+// into an *http.Server.
+func withReadHeaderTimeout(srv *http.Server, cfg ServerConfig) {
+	cfg = newServerConfig(cfg)
+	srv.ReadHeaderTimeout = cfg.ReadHeaderTimeout
+}
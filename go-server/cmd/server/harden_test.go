@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestSessionLimiter_GlobalCap(t *testing.T) {
+	l := newSessionLimiter(newServerConfig(ServerConfig{MaxGlobalSessions: 1, MaxSessionsPerIP: 10}))
+
+	_, ok := l.acquire("1.2.3.4")
+	if !ok {
+		t.Fatal("expected first acquire to succeed")
+	}
+	if _, ok := l.acquire("5.6.7.8"); ok {
+		t.Error("expected second acquire to fail once global cap is reached")
+	}
+}
+
+func TestSessionLimiter_PerIPCap(t *testing.T) {
+	l := newSessionLimiter(newServerConfig(ServerConfig{MaxGlobalSessions: 10, MaxSessionsPerIP: 1}))
+
+	_, ok := l.acquire("1.2.3.4")
+	if !ok {
+		t.Fatal("expected first acquire to succeed")
+	}
+	if _, ok := l.acquire("1.2.3.4"); ok {
+		t.Error("expected second acquire from same IP to fail once per-IP cap is reached")
+	}
+	if _, ok := l.acquire("5.6.7.8"); !ok {
+		t.Error("expected acquire from a different IP to succeed")
+	}
+}
+
+func TestSessionLimiter_ReleaseFreesSlot(t *testing.T) {
+	l := newSessionLimiter(newServerConfig(ServerConfig{MaxGlobalSessions: 1, MaxSessionsPerIP: 1}))
+
+	release, ok := l.acquire("1.2.3.4")
+	if !ok {
+		t.Fatal("expected acquire to succeed")
+	}
+	release()
+
+	if _, ok := l.acquire("1.2.3.4"); !ok {
+		t.Error("expected acquire to succeed again after release")
+	}
+}
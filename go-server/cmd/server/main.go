@@ -0,0 +1,365 @@
+// Command server exposes the Model ID Cheatsheet over MCP (Model Context
+// Protocol), so assistants can look up canonical model IDs instead of
+// guessing at them.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"go-server/internal/models"
+)
+
+const serverVersion = "1.2.1"
+
+// defaultListModelsLimit caps the page size when the caller doesn't specify
+// one, so a naive client can't accidentally pull the whole catalog in one
+// call as the registry grows.
+const defaultListModelsLimit = 50
+
+// listModelsInput is the argument schema for the list_models tool.
+type listModelsInput struct {
+	Provider          string `json:"provider,omitempty" jsonschema:"filter to a single provider (e.g. \"OpenAI\")"`
+	Family            string `json:"family,omitempty" jsonschema:"filter to a single model family (e.g. \"gpt-5\")"`
+	Modality          string `json:"modality,omitempty" jsonschema:"filter to a single modality (e.g. \"text\")"`
+	ContextWindowMin  int    `json:"context_window_min,omitempty" jsonschema:"only return models with at least this context window"`
+	Filter            string `json:"filter,omitempty" jsonschema:"free-text expression, e.g. provider == \"OpenAI\" and context_window > 100000"`
+	Limit             int    `json:"limit,omitempty" jsonschema:"max number of models to return (default 50)"`
+	PageToken         string `json:"page_token,omitempty" jsonschema:"opaque cursor from a previous response's next_page_token"`
+}
+
+// listModelsOutput is the result schema for the list_models tool.
+type listModelsOutput struct {
+	Models        map[string]models.Model `json:"models"`
+	NextPageToken string                  `json:"next_page_token,omitempty"`
+}
+
+// getModelInfoInput is the argument schema for the get_model_info tool.
+type getModelInfoInput struct {
+	ModelID string `json:"model_id" jsonschema:"the model ID to look up"`
+}
+
+// newServer builds a fresh *mcp.Server with its own tool set. A new instance
+// is handed out per SSE/streamable-HTTP session so that one client's state
+// can never leak into another's. m aggregates counters/histograms across
+// every *mcp.Server instance sharing it — callers that want one counter
+// set per process (serveHTTP) pass the same *metricsRegistry to every
+// newServer call; callers that want isolation (tests) pass a fresh one.
+func newServer(m *metricsRegistry) *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{
+		Name:    "model-id-cheatsheet",
+		Version: serverVersion,
+	}, nil)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_models",
+		Description: "List tracked model IDs, optionally filtered by provider, family, modality, minimum context window, or a filter expression. Results are paginated (default 50 per page); pass next_page_token back as page_token for the next page.",
+	}, instrumentTool(m, "list_models", listModelsHandler))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_model_info",
+		Description: "Look up the provider, family, and status for a single model ID.",
+	}, instrumentTool(m, "get_model_info", getModelInfoHandler))
+
+	return server
+}
+
+func listModelsHandler(ctx context.Context, req *mcp.CallToolRequest, in listModelsInput) (*mcp.CallToolResult, listModelsOutput, error) {
+	var expr *filterExpr
+	if in.Filter != "" {
+		var err error
+		expr, err = parseFilterExpr(in.Filter)
+		if err != nil {
+			return &mcp.CallToolResult{IsError: true}, listModelsOutput{}, fmt.Errorf("invalid filter: %w", err)
+		}
+	}
+
+	var ids []string
+	for id, m := range models.Models {
+		if in.Provider != "" && m.Provider != in.Provider {
+			continue
+		}
+		if in.Family != "" && m.Family != in.Family {
+			continue
+		}
+		if in.Modality != "" && m.Modality != in.Modality {
+			continue
+		}
+		if in.ContextWindowMin != 0 && m.ContextWindow < in.ContextWindowMin {
+			continue
+		}
+		if expr != nil && !expr.matches(m) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	// Stable ordering is required so that page tokens round-trip: map
+	// iteration order is randomized, so everything downstream sorts by ID.
+	sort.Strings(ids)
+
+	start := 0
+	if in.PageToken != "" {
+		after, err := decodePageToken(in.PageToken)
+		if err != nil {
+			return &mcp.CallToolResult{IsError: true}, listModelsOutput{}, fmt.Errorf("invalid page_token: %w", err)
+		}
+		start = sort.SearchStrings(ids, after)
+		if start < len(ids) && ids[start] == after {
+			start++
+		}
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultListModelsLimit
+	}
+
+	end := start + limit
+	if end > len(ids) {
+		end = len(ids)
+	}
+	if start > len(ids) {
+		start = len(ids)
+	}
+
+	page := make(map[string]models.Model, end-start)
+	for _, id := range ids[start:end] {
+		page[id] = models.Models[id]
+	}
+
+	out := listModelsOutput{Models: page}
+	if end < len(ids) {
+		out.NextPageToken = encodePageToken(ids[end-1])
+	}
+	return nil, out, nil
+}
+
+// encodePageToken and decodePageToken wrap the cursor value (the last model
+// ID returned on the current page) so it's opaque to callers and safe to
+// pass back verbatim as page_token.
+func encodePageToken(lastID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastID))
+}
+
+func decodePageToken(token string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// filterExpr is a parsed "and"-chain of comparisons over a Model's fields,
+// e.g. `provider == "OpenAI" and context_window > 100000`.
+type filterExpr struct {
+	clauses []filterClause
+}
+
+type filterClause struct {
+	field string
+	op    string
+	value string
+}
+
+var filterOps = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// parseFilterExpr parses a small expression language: clauses joined by
+// "and", each of the form `field op value`, where value is either a quoted
+// string or a bare number.
+func parseFilterExpr(expr string) (*filterExpr, error) {
+	var clauses []filterClause
+	for _, part := range strings.Split(expr, " and ") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		clause, err := parseFilterClause(part)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("empty filter expression")
+	}
+	return &filterExpr{clauses: clauses}, nil
+}
+
+func parseFilterClause(part string) (filterClause, error) {
+	for _, op := range filterOps {
+		idx := strings.Index(part, op)
+		if idx < 0 {
+			continue
+		}
+		field := strings.TrimSpace(part[:idx])
+		value := strings.TrimSpace(part[idx+len(op):])
+		value = strings.Trim(value, `"`)
+		if field == "" || value == "" {
+			return filterClause{}, fmt.Errorf("malformed clause %q", part)
+		}
+		return filterClause{field: field, op: op, value: value}, nil
+	}
+	return filterClause{}, fmt.Errorf("no recognized operator in clause %q", part)
+}
+
+func (e *filterExpr) matches(m models.Model) bool {
+	for _, c := range e.clauses {
+		if !c.matches(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c filterClause) matches(m models.Model) bool {
+	switch c.field {
+	case "provider":
+		return compareString(m.Provider, c.op, c.value)
+	case "family":
+		return compareString(m.Family, c.op, c.value)
+	case "modality":
+		return compareString(m.Modality, c.op, c.value)
+	case "status":
+		return compareString(m.Status, c.op, c.value)
+	case "context_window":
+		n, err := strconv.Atoi(c.value)
+		if err != nil {
+			return false
+		}
+		return compareInt(m.ContextWindow, c.op, n)
+	default:
+		return false
+	}
+}
+
+func compareString(got, op, want string) bool {
+	switch op {
+	case "==":
+		return got == want
+	case "!=":
+		return got != want
+	default:
+		return false
+	}
+}
+
+func compareInt(got int, op string, want int) bool {
+	switch op {
+	case "==":
+		return got == want
+	case "!=":
+		return got != want
+	case ">":
+		return got > want
+	case "<":
+		return got < want
+	case ">=":
+		return got >= want
+	case "<=":
+		return got <= want
+	default:
+		return false
+	}
+}
+
+func getModelInfoHandler(ctx context.Context, req *mcp.CallToolRequest, in getModelInfoInput) (*mcp.CallToolResult, any, error) {
+	m, ok := models.Models[in.ModelID]
+	if !ok {
+		return &mcp.CallToolResult{IsError: true}, map[string]string{"error": "unknown model_id: " + in.ModelID}, nil
+	}
+	return nil, m, nil
+}
+
+// corsMiddleware allows any origin to call the MCP endpoints — the
+// cheatsheet is read-only public data, so there's nothing to protect against
+// cross-origin reads.
+func corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Mcp-Session-Id")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newMux builds the production mux: /health + /sse + /mcp + /metrics. m is
+// the metricsRegistry every handler on this mux reports into; production
+// and each test server get their own so counters never leak between them.
+func newMux(m *metricsRegistry) *http.ServeMux {
+	getServer := func(_ *http.Request) *mcp.Server { return newServer(m) }
+	sseHandler := mcp.NewSSEHandler(getServer, nil)
+	countedSSEHandler := countSSESessions(m, sseHandler)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		health := map[string]any{
+			"status":  "ok",
+			"models":  len(models.Models),
+			"version": serverVersion,
+		}
+		if os.Getenv("HEALTH_DEBUG") == "true" {
+			health["client_ip"] = clientIPFromContext(r.Context())
+		}
+		_ = json.NewEncoder(w).Encode(health)
+	})
+	mux.Handle("/sse", countedSSEHandler)
+	mux.Handle("/sse/", countedSSEHandler)
+	mux.Handle("/mcp", mcp.NewStreamableHTTPHandler(getServer, nil))
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		m.writeTo(w)
+	})
+	return mux
+}
+
+// countSSESessions tracks mcp_sse_sessions_active / mcp_sse_sessions_total:
+// the wrapped handler blocks for the lifetime of the SSE connection, so a
+// session is "open" for as long as ServeHTTP hasn't returned.
+func countSSESessions(m *metricsRegistry, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.sessionOpened()
+		defer m.sessionClosed()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func serveHTTP() error {
+	addr := os.Getenv("PORT")
+	if addr == "" {
+		addr = "8080"
+	}
+	cfg := newServerConfig(ServerConfig{})
+	trustedProxies = parseTrustedProxies(os.Getenv("REAL_IP_TRUSTED_PROXIES"))
+
+	metrics := newMetricsRegistry()
+	mux := newMux(metrics)
+	ssePaths := map[string]bool{"/sse": true, "/sse/": true}
+	authCfg := authConfigFromEnv()
+	handler := realIPMiddleware(trustedProxies)(authMiddleware(authCfg)(corsMiddleware(metricsMiddleware(metrics)(hardenedMux(mux, cfg, ssePaths)))))
+
+	srv := &http.Server{Addr: ":" + addr, Handler: handler}
+	withReadHeaderTimeout(srv, cfg)
+
+	log.Printf("model-id-cheatsheet listening on :%s", addr)
+	return srv.ListenAndServe()
+}
+
+func main() {
+	if err := serveHTTP(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -17,9 +17,10 @@ import (
 )
 
 func TestNewServerReturnsDistinctInstances(t *testing.T) {
-	s1 := newServer()
-	s2 := newServer()
-	s3 := newServer()
+	m := newMetricsRegistry()
+	s1 := newServer(m)
+	s2 := newServer(m)
+	s3 := newServer(m)
 
 	if s1 == s2 {
 		t.Fatal("s1 and s2 are the same instance")
@@ -110,23 +111,24 @@ func TestConcurrentSSESessions(t *testing.T) {
 }
 
 // newTestMux builds the same mux as serveHTTP: /health + /sse + /mcp.
-func newTestMux() *http.ServeMux {
-	getServer := func(_ *http.Request) *mcp.Server { return newServer() }
-	sseHandler := mcp.NewSSEHandler(getServer, nil)
-
-	mux := http.NewServeMux()
-	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(map[string]any{
-			"status":  "ok",
-			"models":  len(models.Models),
-			"version": "1.2.1",
-		})
-	})
-	mux.Handle("/sse", sseHandler)
-	mux.Handle("/sse/", sseHandler)
-	mux.Handle("/mcp", mcp.NewStreamableHTTPHandler(getServer, nil))
-	return mux
+func newTestMux() http.Handler {
+	return newTestMuxWithConfig(ServerConfig{})
+}
+
+// newTestMuxWithConfig is like newTestMux but lets tests exercise
+// hardenedMux's limits directly.
+func newTestMuxWithConfig(cfg ServerConfig) http.Handler {
+	return newTestMuxWithAuth(cfg, AuthConfig{})
+}
+
+// newTestMuxWithAuth is like newTestMux but also lets tests exercise
+// authMiddleware. Each call gets its own metricsRegistry, so counters from
+// one test server never leak into another's.
+func newTestMuxWithAuth(cfg ServerConfig, authCfg AuthConfig) http.Handler {
+	metrics := newMetricsRegistry()
+	mux := newMux(metrics)
+	ssePaths := map[string]bool{"/sse": true, "/sse/": true}
+	return realIPMiddleware(nil)(authMiddleware(authCfg)(corsMiddleware(metricsMiddleware(metrics)(hardenedMux(mux, cfg, ssePaths)))))
 }
 
 func TestHealthEndpoint(t *testing.T) {
@@ -169,6 +171,29 @@ func TestHealthEndpoint(t *testing.T) {
 	}
 }
 
+func TestHealthExposesClientIPWhenDebugEnabled(t *testing.T) {
+	t.Setenv("HEALTH_DEBUG", "true")
+
+	mux := newMux(newMetricsRegistry())
+	handler := realIPMiddleware(nil)(mux)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/health")
+	if err != nil {
+		t.Fatalf("GET /health failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var health map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		t.Fatalf("health response is not valid JSON: %v", err)
+	}
+	if health["client_ip"] == nil || health["client_ip"] == "" {
+		t.Errorf("expected client_ip in health response, got %v", health)
+	}
+}
+
 func TestHealthDoesNotAffectSSE(t *testing.T) {
 	srv := httptest.NewServer(newTestMux())
 	defer srv.Close()
@@ -203,6 +228,70 @@ func TestHealthDoesNotAffectSSE(t *testing.T) {
 	}
 }
 
+// TestHardenedMux_SessionCapRejectsExcessClients spins up N+1 SSE clients
+// against a global cap of N and asserts the last one gets 503.
+func TestHardenedMux_SessionCapRejectsExcessClients(t *testing.T) {
+	const cap = 2
+	srv := httptest.NewServer(newTestMuxWithConfig(ServerConfig{MaxGlobalSessions: cap}))
+	defer srv.Close()
+
+	var open []*http.Response
+	defer func() {
+		for _, r := range open {
+			r.Body.Close()
+		}
+	}()
+
+	for i := 0; i < cap; i++ {
+		resp, err := http.Get(srv.URL + "/sse")
+		if err != nil {
+			t.Fatalf("client %d: GET /sse failed: %v", i, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("client %d: expected 200, got %d", i, resp.StatusCode)
+		}
+		open = append(open, resp)
+	}
+
+	resp, err := http.Get(srv.URL + "/sse")
+	if err != nil {
+		t.Fatalf("overflow client: GET /sse failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 once cap is exceeded, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("expected Retry-After header on 503 response")
+	}
+}
+
+// TestHardenedMux_OversizedBodyRejected posts an oversized /mcp payload and
+// expects 413.
+func TestHardenedMux_OversizedBodyRejected(t *testing.T) {
+	srv := httptest.NewServer(newTestMuxWithConfig(ServerConfig{MaxBodyBytes: 16}))
+	defer srv.Close()
+
+	body := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/mcp", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for oversized body, got %d", resp.StatusCode)
+	}
+}
+
 func TestCORSPreflight(t *testing.T) {
 	srv := httptest.NewServer(corsMiddleware(newTestMux()))
 	defer srv.Close()
@@ -228,6 +317,101 @@ func TestCORSPreflight(t *testing.T) {
 	}
 }
 
+// TestListModelsFilteringAndPagination opens several concurrent SSE sessions
+// and drives them through filtered, paginated list_models calls, checking
+// that a full first page comes back with a next_page_token and that passing
+// that token back yields a second page with no model repeated from the first.
+func TestListModelsFilteringAndPagination(t *testing.T) {
+	ts := httptest.NewServer(newTestMux())
+	defer ts.Close()
+
+	const numClients = 3
+	var wg sync.WaitGroup
+	errs := make(chan error, numClients)
+
+	for i := range numClients {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+
+			ctx := context.Background()
+			transport := &mcp.SSEClientTransport{Endpoint: ts.URL + "/sse"}
+			client := mcp.NewClient(&mcp.Implementation{Name: fmt.Sprintf("pager-%d", id), Version: "1.0.1"}, nil)
+
+			session, err := client.Connect(ctx, transport, nil)
+			if err != nil {
+				errs <- fmt.Errorf("client %d: connect: %w", id, err)
+				return
+			}
+			defer session.Close()
+
+			res, err := session.CallTool(ctx, &mcp.CallToolParams{
+				Name:      "list_models",
+				Arguments: map[string]any{"provider": "OpenAI", "limit": 5},
+			})
+			if err != nil {
+				errs <- fmt.Errorf("client %d: list_models page 1: %w", id, err)
+				return
+			}
+
+			var page1 listModelsOutput
+			if err := decodeStructuredContent(res, &page1); err != nil {
+				errs <- fmt.Errorf("client %d: decode page 1: %w", id, err)
+				return
+			}
+			if len(page1.Models) != 5 {
+				errs <- fmt.Errorf("client %d: expected 5 models on page 1, got %d", id, len(page1.Models))
+				return
+			}
+			if page1.NextPageToken == "" {
+				errs <- fmt.Errorf("client %d: expected next_page_token on page 1", id)
+				return
+			}
+
+			res, err = session.CallTool(ctx, &mcp.CallToolParams{
+				Name:      "list_models",
+				Arguments: map[string]any{"provider": "OpenAI", "limit": 5, "page_token": page1.NextPageToken},
+			})
+			if err != nil {
+				errs <- fmt.Errorf("client %d: list_models page 2: %w", id, err)
+				return
+			}
+
+			var page2 listModelsOutput
+			if err := decodeStructuredContent(res, &page2); err != nil {
+				errs <- fmt.Errorf("client %d: decode page 2: %w", id, err)
+				return
+			}
+			for mid := range page1.Models {
+				if _, dup := page2.Models[mid]; dup {
+					errs <- fmt.Errorf("client %d: model %q appeared on both pages", id, mid)
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// decodeStructuredContent unmarshals a CallToolResult's first text content
+// block into v.
+func decodeStructuredContent(res *mcp.CallToolResult, v any) error {
+	if len(res.Content) == 0 {
+		return fmt.Errorf("empty content")
+	}
+	text, ok := res.Content[0].(*mcp.TextContent)
+	if !ok {
+		return fmt.Errorf("content[0] is not text")
+	}
+	return json.Unmarshal([]byte(text.Text), v)
+}
+
 func TestStreamableHTTPEndpoint(t *testing.T) {
 	srv := httptest.NewServer(newTestMux())
 	defer srv.Close()
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// metricsRegistry holds every counter/gauge/histogram exposed on /metrics.
+// It's deliberately hand-rolled rather than pulling in
+// github.com/prometheus/client_golang, since the cheatsheet only needs a
+// handful of series and the text exposition format is simple enough to
+// render directly.
+type metricsRegistry struct {
+	mu sync.Mutex
+
+	toolCallsTotal   map[toolStatusKey]int64
+	toolCallDuration map[string]*toolHistogram // per tool
+	sseSessionsTotal int64
+	sseSessionsNow   int64
+	httpRequests     map[httpKey]int64
+}
+
+// toolHistogram accumulates a tool's call durations into
+// toolCallDurationBuckets' fixed bucket counts plus a running sum/count,
+// rather than keeping every sample for the life of the process — this
+// server runs long enough that an unbounded []float64 per tool would grow
+// without limit and make every /metrics scrape slower.
+type toolHistogram struct {
+	bucketCounts []int64 // parallel to toolCallDurationBuckets; counts[i] = samples <= toolCallDurationBuckets[i]
+	sum          float64
+	count        int64
+}
+
+func (h *toolHistogram) observe(seconds float64) {
+	h.sum += seconds
+	h.count++
+	for i, bucket := range toolCallDurationBuckets {
+		if seconds <= bucket {
+			h.bucketCounts[i]++
+		}
+	}
+}
+
+type toolStatusKey struct {
+	tool   string
+	status string // "ok" or "error"
+}
+
+type httpKey struct {
+	path   string
+	method string
+	status int
+}
+
+// toolCallDurationBuckets mirrors the default Prometheus histogram buckets,
+// in seconds.
+var toolCallDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{
+		toolCallsTotal:   make(map[toolStatusKey]int64),
+		toolCallDuration: make(map[string]*toolHistogram),
+		httpRequests:     make(map[httpKey]int64),
+	}
+}
+
+func (m *metricsRegistry) observeToolCall(tool, status string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.toolCallsTotal[toolStatusKey{tool: tool, status: status}]++
+	h, ok := m.toolCallDuration[tool]
+	if !ok {
+		h = &toolHistogram{bucketCounts: make([]int64, len(toolCallDurationBuckets))}
+		m.toolCallDuration[tool] = h
+	}
+	h.observe(d.Seconds())
+}
+
+func (m *metricsRegistry) sessionOpened() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sseSessionsTotal++
+	m.sseSessionsNow++
+}
+
+func (m *metricsRegistry) sessionClosed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sseSessionsNow--
+}
+
+func (m *metricsRegistry) observeHTTPRequest(path, method string, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.httpRequests[httpKey{path: path, method: method, status: status}]++
+}
+
+// instrumentTool wraps a tool handler so every call is timed and counted.
+// Consul's convention of reporting sub-millisecond durations as decimals
+// (rather than truncating to zero) is followed here by keeping everything
+// in float64 seconds.
+func instrumentTool[In, Out any](m *metricsRegistry, tool string, handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, in In) (*mcp.CallToolResult, Out, error) {
+		start := time.Now()
+		res, out, err := handler(ctx, req, in)
+		status := "ok"
+		if err != nil || (res != nil && res.IsError) {
+			status = "error"
+		}
+		m.observeToolCall(tool, status, time.Since(start))
+		return res, out, err
+	}
+}
+
+// writeTo renders the registry in Prometheus text exposition format.
+func (m *metricsRegistry) writeTo(w http.ResponseWriter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintln(w, "# HELP mcp_tool_calls_total Total number of MCP tool calls.")
+	fmt.Fprintln(w, "# TYPE mcp_tool_calls_total counter")
+	for _, k := range sortedToolStatusKeys(m.toolCallsTotal) {
+		fmt.Fprintf(w, "mcp_tool_calls_total{tool=%q,status=%q} %d\n", k.tool, k.status, m.toolCallsTotal[k])
+	}
+
+	fmt.Fprintln(w, "# HELP mcp_tool_call_duration_seconds Duration of MCP tool calls.")
+	fmt.Fprintln(w, "# TYPE mcp_tool_call_duration_seconds histogram")
+	for _, tool := range sortedStringKeys(m.toolCallDuration) {
+		writeHistogram(w, "mcp_tool_call_duration_seconds", tool, m.toolCallDuration[tool])
+	}
+
+	fmt.Fprintln(w, "# HELP mcp_sse_sessions_active Number of currently active SSE sessions.")
+	fmt.Fprintln(w, "# TYPE mcp_sse_sessions_active gauge")
+	fmt.Fprintf(w, "mcp_sse_sessions_active %d\n", m.sseSessionsNow)
+
+	fmt.Fprintln(w, "# HELP mcp_sse_sessions_total Total number of SSE sessions opened.")
+	fmt.Fprintln(w, "# TYPE mcp_sse_sessions_total counter")
+	fmt.Fprintf(w, "mcp_sse_sessions_total %d\n", m.sseSessionsTotal)
+
+	fmt.Fprintln(w, "# HELP http_requests_total Total number of HTTP requests.")
+	fmt.Fprintln(w, "# TYPE http_requests_total counter")
+	for _, k := range sortedHTTPKeys(m.httpRequests) {
+		fmt.Fprintf(w, "http_requests_total{path=%q,method=%q,status=\"%d\"} %d\n", k.path, k.method, k.status, m.httpRequests[k])
+	}
+}
+
+func writeHistogram(w http.ResponseWriter, name, tool string, h *toolHistogram) {
+	for i, bucket := range toolCallDurationBuckets {
+		fmt.Fprintf(w, "%s_bucket{tool=%q,le=%q} %d\n", name, tool, fmt.Sprintf("%g", bucket), h.bucketCounts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{tool=%q,le=\"+Inf\"} %d\n", name, tool, h.count)
+	fmt.Fprintf(w, "%s_sum{tool=%q} %g\n", name, tool, h.sum)
+	fmt.Fprintf(w, "%s_count{tool=%q} %d\n", name, tool, h.count)
+}
+
+func sortedToolStatusKeys(m map[toolStatusKey]int64) []toolStatusKey {
+	keys := make([]toolStatusKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].tool != keys[j].tool {
+			return keys[i].tool < keys[j].tool
+		}
+		return keys[i].status < keys[j].status
+	})
+	return keys
+}
+
+func sortedStringKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// metricsMiddleware records http_requests_total for every request that
+// passes through it, into m.
+func metricsMiddleware(m *metricsRegistry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sw := &statusCapturingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+			m.observeHTTPRequest(r.URL.Path, r.Method, sw.status)
+		})
+	}
+}
+
+// statusCapturingWriter records the status code passed to WriteHeader so
+// metricsMiddleware can label http_requests_total correctly.
+type statusCapturingWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusCapturingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap exposes the underlying ResponseWriter so http.NewResponseController
+// (used by the SSE transport to Flush after every event) can find the real
+// Flusher/Hijacker instead of stopping at this wrapper.
+func (w *statusCapturingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func sortedHTTPKeys(m map[httpKey]int64) []httpKey {
+	keys := make([]httpKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path != keys[j].path {
+			return keys[i].path < keys[j].path
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+	return keys
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TestMetricsEndpoint calls list_models and get_model_info a few times over
+// SSE, then scrapes /metrics and asserts the expected series are present
+// with non-zero counts.
+func TestMetricsEndpoint(t *testing.T) {
+	ts := httptest.NewServer(newTestMux())
+	defer ts.Close()
+
+	ctx := context.Background()
+	transport := &mcp.SSEClientTransport{Endpoint: ts.URL + "/sse"}
+	client := mcp.NewClient(&mcp.Implementation{Name: "metrics-test", Version: "1.0.1"}, nil)
+
+	session, err := client.Connect(ctx, transport, nil)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer session.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "list_models"}); err != nil {
+			t.Fatalf("list_models call %d: %v", i, err)
+		}
+	}
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_model_info",
+		Arguments: map[string]any{"model_id": "gpt-5.2"},
+	}); err != nil {
+		t.Fatalf("get_model_info: %v", err)
+	}
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading /metrics body: %v", err)
+	}
+	text := string(body)
+
+	for _, want := range []string{
+		`mcp_tool_calls_total{tool="list_models",status="ok"} 3`,
+		`mcp_tool_calls_total{tool="get_model_info",status="ok"} 1`,
+		"mcp_tool_call_duration_seconds_count",
+		"mcp_sse_sessions_active",
+		"mcp_sse_sessions_total",
+		"http_requests_total",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected /metrics to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestMetricsRegistry_HistogramReflectsSubMillisecondDurations(t *testing.T) {
+	m := newMetricsRegistry()
+	m.observeToolCall("list_models", "ok", 0)
+
+	w := httptest.NewRecorder()
+	m.writeTo(w)
+
+	text := w.Body.String()
+	if !strings.Contains(text, `mcp_tool_call_duration_seconds_count{tool="list_models"} 1`) {
+		t.Errorf("expected histogram count of 1, got:\n%s", text)
+	}
+	if !strings.Contains(text, fmt.Sprintf(`mcp_tool_call_duration_seconds_bucket{tool="list_models",le="%g"}`, toolCallDurationBuckets[0])) {
+		t.Errorf("expected a bucket for the smallest threshold, got:\n%s", text)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// realIPContextKey is the context key under which realIPMiddleware stores
+// the resolved client IP.
+type realIPContextKey struct{}
+
+// trustedProxies is the set of CIDR ranges allowed to supply
+// X-Forwarded-For / X-Real-IP. Populated from REAL_IP_TRUSTED_PROXIES
+// (comma-separated CIDRs) at startup; empty by default, which means the
+// middleware never trusts forwarded headers and always uses RemoteAddr.
+var trustedProxies []*net.IPNet
+
+// parseTrustedProxies parses a comma-separated list of CIDRs, e.g.
+// "10.0.0.0/8,172.16.0.0/12". Malformed entries are skipped.
+func parseTrustedProxies(csv string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, raw := range strings.Split(csv, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if !strings.Contains(raw, "/") {
+			if ip := net.ParseIP(raw); ip != nil {
+				bits := 32
+				if ip.To4() == nil {
+					bits = 128
+				}
+				raw = raw + "/" + strconv.Itoa(bits)
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(raw)
+		if err != nil {
+			continue
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+func isTrustedProxy(ip net.IP, trusted []*net.IPNet) bool {
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// realIPMiddleware resolves the true client IP when the server sits behind
+// a reverse proxy. It walks X-Forwarded-For right-to-left, skipping hops
+// that are themselves trusted proxies, falls back to X-Real-IP, and finally
+// to RemoteAddr. If the direct peer (RemoteAddr) is not a trusted proxy,
+// forwarded headers are ignored entirely to prevent spoofing.
+func realIPMiddleware(trusted []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resolved := resolveClientIP(r, trusted)
+			r.RemoteAddr = net.JoinHostPort(resolved, remotePort(r.RemoteAddr))
+			ctx := context.WithValue(r.Context(), realIPContextKey{}, resolved)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// resolveClientIP implements the trusted-proxy walk described above.
+func resolveClientIP(r *http.Request, trusted []*net.IPNet) string {
+	peerHost, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		peerHost = r.RemoteAddr
+	}
+	peerIP := net.ParseIP(peerHost)
+
+	if peerIP == nil || !isTrustedProxy(peerIP, trusted) {
+		if peerIP != nil {
+			return peerIP.String()
+		}
+		return peerHost
+	}
+
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		hops := strings.Split(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			ip := net.ParseIP(hop)
+			if ip == nil {
+				continue
+			}
+			if isTrustedProxy(ip, trusted) {
+				continue
+			}
+			return ip.String()
+		}
+		// Every hop was trusted (or unparsable) — use the leftmost address.
+		if ip := net.ParseIP(strings.TrimSpace(hops[0])); ip != nil {
+			return ip.String()
+		}
+	}
+
+	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+		if ip := net.ParseIP(strings.TrimSpace(realIP)); ip != nil {
+			return ip.String()
+		}
+	}
+
+	return peerIP.String()
+}
+
+// clientIPFromContext returns the IP resolved by realIPMiddleware, or "" if
+// the middleware hasn't run.
+func clientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(realIPContextKey{}).(string)
+	return ip
+}
+
+func remotePort(remoteAddr string) string {
+	_, port, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return "0"
+	}
+	return port
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRealIPMiddleware_UntrustedPeerIgnoresHeaders(t *testing.T) {
+	trusted := parseTrustedProxies("10.0.0.0/8")
+
+	var got string
+	handler := realIPMiddleware(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = clientIPFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.RemoteAddr = "203.0.113.5:54321"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "203.0.113.5" {
+		t.Errorf("expected untrusted peer's own address, got %q", got)
+	}
+}
+
+func TestRealIPMiddleware_TrustedChainSelectsLeftmostUntrusted(t *testing.T) {
+	trusted := parseTrustedProxies("10.0.0.1/32,10.0.0.2/32")
+
+	var got string
+	handler := realIPMiddleware(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = clientIPFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.RemoteAddr = "10.0.0.2:9999"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1, 10.0.0.1, 10.0.0.2")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "198.51.100.1" {
+		t.Errorf("expected leftmost untrusted address, got %q", got)
+	}
+}
+
+func TestRealIPMiddleware_UsesXRealIPWhenXFFAbsent(t *testing.T) {
+	trusted := parseTrustedProxies("10.0.0.1/32")
+
+	var got string
+	handler := realIPMiddleware(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = clientIPFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.RemoteAddr = "10.0.0.1:9999"
+	req.Header.Set("X-Real-IP", "198.51.100.7")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "198.51.100.7" {
+		t.Errorf("expected X-Real-IP address, got %q", got)
+	}
+}
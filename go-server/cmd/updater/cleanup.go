@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-server/internal/forge"
+)
+
+const (
+	autoDeprecateBranchPrefix = "auto-deprecate-"
+	defaultCleanupMaxAgeDays  = 14
+)
+
+var (
+	cleanupFlag           = flag.Bool("cleanup", true, "clean up stale auto-deprecate branches and superseded auto-update issues")
+	dryRunFlag            = flag.Bool("dry-run", false, "log what --cleanup would do without deleting branches or closing issues")
+	cleanupMaxAgeDaysFlag = flag.Int("cleanup-max-age-days", defaultCleanupMaxAgeDays, "minimum age, in days, before a merged/closed auto-deprecate branch or a superseded issue is cleaned up")
+)
+
+// runCleanup deletes auto-deprecate branches whose PR has merged or closed
+// and are older than --cleanup-max-age-days, and closes auto-update issues
+// from prior weeks once the current week's rolling issue (see
+// createGitHubIssue) has superseded them. It's a no-op if --cleanup is off
+// or no forge is configured.
+func runCleanup(ctx context.Context) {
+	if !*cleanupFlag {
+		return
+	}
+	f := forgeFromEnv()
+	if f == nil {
+		return
+	}
+	cleanupStaleBranches(ctx, f)
+	cleanupStaleIssues(ctx, f)
+}
+
+func cleanupStaleBranches(ctx context.Context, f forge.Forge) {
+	branches, err := f.ListBranches(ctx, autoDeprecateBranchPrefix)
+	if err != nil {
+		fmt.Printf("[Cleanup] failed to list branches: %v\n", err)
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -*cleanupMaxAgeDaysFlag)
+	for _, b := range branches {
+		created, ok := branchCreatedDate(b.Name)
+		if !ok || created.After(cutoff) {
+			continue
+		}
+
+		state, err := f.PullRequestStateForBranch(ctx, b.Name)
+		if err != nil {
+			fmt.Printf("[Cleanup] %s: failed to check PR state: %v\n", b.Name, err)
+			continue
+		}
+		if state != "merged" && state != "closed" {
+			continue
+		}
+
+		if *dryRunFlag {
+			fmt.Printf("[Cleanup] (dry-run) would delete branch %s (PR %s)\n", b.Name, state)
+			continue
+		}
+		if err := f.DeleteBranch(ctx, b.Name); err != nil {
+			fmt.Printf("[Cleanup] failed to delete branch %s: %v\n", b.Name, err)
+			continue
+		}
+		fmt.Printf("[Cleanup] deleted stale branch %s (PR %s)\n", b.Name, state)
+	}
+}
+
+// branchCreatedDate recovers the date embedded in an
+// "auto-deprecate-YYYY-MM-DD" branch name.
+func branchCreatedDate(name string) (time.Time, bool) {
+	datePart, ok := strings.CutPrefix(name, autoDeprecateBranchPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", datePart)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func cleanupStaleIssues(ctx context.Context, f forge.Forge) {
+	issues, err := f.ListIssues(ctx, "auto-update")
+	if err != nil {
+		fmt.Printf("[Cleanup] failed to list issues: %v\n", err)
+		return
+	}
+
+	currentWeekTitle := "Model Update Report - " + weekKey(time.Now())
+	cutoff := time.Now().AddDate(0, 0, -*cleanupMaxAgeDaysFlag)
+	for _, issue := range issues {
+		if issue.State != "open" || issue.Title == currentWeekTitle || issue.CreatedAt.After(cutoff) {
+			continue
+		}
+
+		if *dryRunFlag {
+			fmt.Printf("[Cleanup] (dry-run) would close superseded issue #%d (%s)\n", issue.Number, issue.Title)
+			continue
+		}
+		if err := f.CloseIssue(ctx, issue.Number); err != nil {
+			fmt.Printf("[Cleanup] failed to close issue #%d: %v\n", issue.Number, err)
+			continue
+		}
+		fmt.Printf("[Cleanup] closed superseded issue #%d (%s)\n", issue.Number, issue.Title)
+	}
+}
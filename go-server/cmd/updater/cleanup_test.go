@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBranchCreatedDate_ParsesAutoDeprecateName(t *testing.T) {
+	got, ok := branchCreatedDate("auto-deprecate-2026-07-01")
+	if !ok {
+		t.Fatal("expected branchCreatedDate to parse a well-formed name")
+	}
+	want := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestBranchCreatedDate_RejectsOtherBranches(t *testing.T) {
+	if _, ok := branchCreatedDate("main"); ok {
+		t.Error("expected branchCreatedDate to reject a non-matching branch name")
+	}
+	if _, ok := branchCreatedDate("auto-deprecate-not-a-date"); ok {
+		t.Error("expected branchCreatedDate to reject a malformed date suffix")
+	}
+}
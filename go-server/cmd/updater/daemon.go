@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"time"
+
+	"go-server/internal/notify"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultCheckInterval = 15 * time.Minute
+	defaultRatePerMinute = 20.0
+	maxJitter            = 30 * time.Second
+	defaultDaemonAddr    = ":9103"
+	jobQueueCapacity     = 64
+	cleanupInterval      = 24 * time.Hour
+
+	daemonSecretHeader    = "X-Daemon-Secret"
+	daemonSecretQueryName = "secret"
+)
+
+// checkOutcome is the result of checking one provider in daemon mode, as
+// returned by runDaemonCheck and recorded by daemonMetrics.
+type checkOutcome struct {
+	Provider string
+	New      []string
+	Missing  []string
+	Attempts int
+	Err      error
+}
+
+// runDaemonCheck checks a single provider and reports the outcome to the
+// configured notifiers, mirroring the per-provider body of main()'s
+// one-shot loop.
+func runDaemonCheck(ctx context.Context, client *http.Client, name string) checkOutcome {
+	p, ok := providers[name]
+	if !ok {
+		return checkOutcome{Provider: name, Err: fmt.Errorf("no model-listing API configured for %q", name)}
+	}
+	key := os.Getenv(p.AuthEnv)
+	if key == "" {
+		return checkOutcome{Provider: name, Err: fmt.Errorf("%s not set", p.AuthEnv)}
+	}
+
+	ids, attempts, err := fetchModelsWithRetry(ctx, client, name, p, key)
+	if err != nil {
+		notifyReport(ctx, notify.Report{Provider: name, Errors: []string{err.Error()}})
+		return checkOutcome{Provider: name, Attempts: attempts, Err: err}
+	}
+
+	newModels, missing := diffProvider(name, knownModels[name], ids)
+	if len(newModels) > 0 || len(missing) > 0 {
+		notifyReport(ctx, notify.Report{Provider: name, NewIDs: newModels, MissingIDs: missing})
+	}
+	return checkOutcome{Provider: name, New: newModels, Missing: missing, Attempts: attempts}
+}
+
+// runServe starts --serve (daemon) mode: a scheduler goroutine per
+// provider feeds a bounded job queue, a single worker drains it through
+// each provider's rate limiter, and an HTTP server exposes /metrics,
+// /healthz, and a guarded /run trigger.
+func runServe(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	metrics := newDaemonMetrics()
+	jobs := make(chan string, jobQueueCapacity)
+	limiters := make(map[string]*rate.Limiter, len(providerOrder))
+
+	for _, name := range providerOrder {
+		perMinute := providers[name].RateLimitPerMinute
+		if perMinute <= 0 {
+			perMinute = defaultRatePerMinute
+		}
+		limiters[name] = rate.NewLimiter(rate.Limit(perMinute/60.0), 1)
+
+		sched, err := resolveSchedule(providers[name].Schedule)
+		if err != nil {
+			fmt.Printf("[Daemon] %s: %v — using the default interval\n", name, err)
+			sched = schedule{interval: defaultCheckInterval}
+		}
+		go scheduleProvider(ctx, name, sched, jobs)
+	}
+
+	go func() {
+		for name := range jobs {
+			if lim, ok := limiters[name]; ok {
+				if err := lim.Wait(ctx); err != nil {
+					return // ctx cancelled
+				}
+			}
+			metrics.record(runDaemonCheck(ctx, client, name))
+		}
+	}()
+
+	go runPeriodicCleanup(ctx)
+
+	addr := os.Getenv("DAEMON_ADDR")
+	if addr == "" {
+		addr = defaultDaemonAddr
+	}
+	secret := os.Getenv("DAEMON_SECRET")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		metrics.writeTo(w)
+	})
+	mux.HandleFunc("/run", func(w http.ResponseWriter, r *http.Request) {
+		handleRunTrigger(w, r, secret, jobs)
+	})
+
+	fmt.Printf("[Daemon] listening on %s (%d providers scheduled)\n", addr, len(providerOrder))
+	return http.ListenAndServe(addr, mux)
+}
+
+// runPeriodicCleanup runs the stale-branch/stale-issue cleanup pass once
+// at daemon startup and then every cleanupInterval, instead of only at the
+// end of a one-shot run.
+func runPeriodicCleanup(ctx context.Context) {
+	runCleanup(ctx)
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCleanup(ctx)
+		}
+	}
+}
+
+// resolveSchedule parses a provider's configured schedule, treating an
+// empty string as "use the daemon default" rather than an error.
+func resolveSchedule(expr string) (schedule, error) {
+	if expr == "" {
+		return schedule{interval: defaultCheckInterval}, nil
+	}
+	return parseSchedule(expr)
+}
+
+// scheduleProvider pushes name onto jobs each time its schedule fires,
+// after an initial random jitter so providers sharing a start time don't
+// all hit their APIs in the same instant.
+func scheduleProvider(ctx context.Context, name string, sched schedule, jobs chan<- string) {
+	jitter := time.Duration(rand.Int63n(int64(maxJitter)))
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(jitter):
+	}
+
+	for {
+		select {
+		case jobs <- name:
+		case <-ctx.Done():
+			return
+		}
+
+		next := sched.next(time.Now())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// handleRunTrigger enqueues an immediate check for ?provider=X, guarded by
+// the DAEMON_SECRET shared secret (checked via the X-Daemon-Secret header
+// or a ?secret= query parameter). If DAEMON_SECRET isn't set, the trigger
+// is disabled entirely rather than left open.
+func handleRunTrigger(w http.ResponseWriter, r *http.Request, secret string, jobs chan<- string) {
+	if secret == "" {
+		http.Error(w, "trigger disabled: DAEMON_SECRET is not configured", http.StatusServiceUnavailable)
+		return
+	}
+	given := r.Header.Get(daemonSecretHeader)
+	if given == "" {
+		given = r.URL.Query().Get(daemonSecretQueryName)
+	}
+	if subtle.ConstantTimeCompare([]byte(given), []byte(secret)) != 1 {
+		http.Error(w, "invalid secret", http.StatusUnauthorized)
+		return
+	}
+
+	provider := r.URL.Query().Get("provider")
+	if provider == "" {
+		http.Error(w, "missing ?provider= parameter", http.StatusBadRequest)
+		return
+	}
+	if _, ok := providers[provider]; !ok {
+		http.Error(w, fmt.Sprintf("unknown provider %q", provider), http.StatusNotFound)
+		return
+	}
+
+	select {
+	case jobs <- provider:
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("queued\n"))
+	default:
+		http.Error(w, "job queue is full, try again shortly", http.StatusServiceUnavailable)
+	}
+}
@@ -1,18 +1,22 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"regexp"
 	"sort"
 	"strings"
 	"time"
+
+	"go-server/internal/datapatch"
+	"go-server/internal/forge"
+	"go-server/internal/notify"
+	providerreg "go-server/internal/providers"
+	"go-server/internal/registry"
 )
 
 // Provider describes how to query a provider's model listing API.
@@ -20,107 +24,155 @@ type Provider struct {
 	URL        string
 	AuthEnv    string
 	AuthHeader string // empty means use query param auth (Google)
+
+	// Schedule and RateLimitPerMinute are only consulted in --serve
+	// (daemon) mode; see schedule.go and daemon.go.
+	Schedule           string
+	RateLimitPerMinute float64
+}
+
+// defaultRegistryPath is where registry.yaml lives relative to this
+// package; REGISTRY_CONFIG or --config override it.
+const defaultRegistryPath = "registry.yaml"
+
+// providers and knownModels are populated from registry.yaml at package
+// init so both main() and the test suite see the same data without every
+// caller threading a *registry.Registry through.
+var (
+	providers     map[string]Provider
+	knownModels   map[string]map[string]bool
+	providerOrder []string
+	dispatcher    *notify.Dispatcher
+	// modelMeta carries each tracked model ID's full registry metadata
+	// (Replacement, Family, Modality, ...) so callers like the deprecation
+	// patcher can look up a model's curated details without re-walking
+	// the registry's provider -> models tree.
+	modelMeta map[string]registry.ModelEntry
+)
+
+func init() {
+	reg, err := loadRegistry()
+	if err != nil {
+		fmt.Printf("[Registry] %v — falling back to the embedded default registry\n", err)
+		reg, err = registry.Default()
+		if err != nil {
+			fmt.Printf("[Registry] embedded default registry failed to parse: %v — starting empty\n", err)
+			reg = &registry.Registry{}
+		}
+	}
+	providers, knownModels, providerOrder = loadFromRegistry(reg)
+	modelMeta = flattenModelEntries(reg)
+	providerreg.Build(reg)
+
+	sinks, err := notify.SinksFromConfigs(reg.Notifiers)
+	if err != nil {
+		fmt.Printf("[Notify] %v — notifications disabled\n", err)
+	}
+	dispatcher = notify.NewDispatcher(sinks)
+}
+
+// flattenModelEntries indexes every provider's tracked models by ID, for
+// lookups that don't care which provider a model belongs to.
+func flattenModelEntries(reg *registry.Registry) map[string]registry.ModelEntry {
+	out := make(map[string]registry.ModelEntry)
+	for _, p := range reg.Providers {
+		for _, m := range p.Models {
+			out[m.ID] = m
+		}
+	}
+	return out
+}
+
+// registryConfigPath resolves the registry file path: --config flag if
+// present, else REGISTRY_CONFIG, else defaultRegistryPath. Flags aren't
+// parsed yet during init(), so this inspects os.Args directly.
+func registryConfigPath() string {
+	if path := argValue("--config", "-config"); path != "" {
+		return path
+	}
+	if path := os.Getenv("REGISTRY_CONFIG"); path != "" {
+		return path
+	}
+	return defaultRegistryPath
+}
+
+// registryURL resolves --registry-url/REGISTRY_URL the same way
+// registryConfigPath resolves --config; empty means "not configured".
+func registryURL() string {
+	if url := argValue("--registry-url", "-registry-url"); url != "" {
+		return url
+	}
+	return os.Getenv("REGISTRY_URL")
+}
+
+// argValue returns the value passed to whichever of the given flag
+// spellings (e.g. "--config", "-config") appears in os.Args, checking
+// both "--flag value" and "--flag=value" forms. Used during init(),
+// before flag.Parse has run.
+func argValue(spellings ...string) string {
+	for i, arg := range os.Args {
+		for _, name := range spellings {
+			if arg == name && i+1 < len(os.Args) {
+				return os.Args[i+1]
+			}
+			if strings.HasPrefix(arg, name+"=") {
+				return strings.TrimPrefix(arg, name+"=")
+			}
+		}
+	}
+	return ""
 }
 
-var providers = map[string]Provider{
-	"OpenAI":    {URL: "https://api.openai.com/v1/models", AuthEnv: "OPENAI_API_KEY", AuthHeader: "Authorization"},
-	"Anthropic": {URL: "https://api.anthropic.com/v1/models?limit=1000", AuthEnv: "ANTHROPIC_API_KEY", AuthHeader: "x-api-key"},
-	"Google":    {URL: "https://generativelanguage.googleapis.com/v1beta/models", AuthEnv: "GEMINI_API_KEY", AuthHeader: ""},
-	"Mistral":   {URL: "https://api.mistral.ai/v1/models", AuthEnv: "MISTRAL_API_KEY", AuthHeader: "Authorization"},
-	"xAI":       {URL: "https://api.x.ai/v1/models", AuthEnv: "XAI_API_KEY", AuthHeader: "Authorization"},
-	"DeepSeek":  {URL: "https://api.deepseek.com/models", AuthEnv: "DEEPSEEK_API_KEY", AuthHeader: "Authorization"},
+// loadRegistry loads the registry from --registry-url if configured,
+// otherwise from the --config/REGISTRY_CONFIG file path.
+func loadRegistry() (*registry.Registry, error) {
+	if url := registryURL(); url != "" {
+		return registry.LoadURL(url)
+	}
+	return registry.Load(registryConfigPath())
 }
 
-// knownModels maps provider -> set of model IDs we track in the registry.
-var knownModels = map[string]map[string]bool{
-	"OpenAI": {
-		"gpt-5.2":        true,
-		"gpt-5.2-codex":  true,
-		"gpt-5.2-pro":    true,
-		"gpt-5.1":        true,
-		"gpt-5":          true,
-		"gpt-5-mini":     true,
-		"gpt-5-nano":     true,
-		"gpt-4.1-mini":   true,
-		"gpt-4.1-nano":   true,
-		"o3":             true,
-		"o3-pro":         true,
-		"o4-mini":        true,
-		"o3-mini":        true,
-		"gpt-4.1":        true,
-		"gpt-4o":         true,
-		"gpt-4o-mini":    true,
-	},
-	"Anthropic": {
-		"claude-opus-4-6":              true,
-		"claude-sonnet-4-5-20250929":   true,
-		"claude-haiku-4-5-20251001":    true,
-		"claude-opus-4-5":              true,
-		"claude-opus-4-1":              true,
-		"claude-sonnet-4-0":            true,
-		"claude-3-7-sonnet-20250219":   true,
-		"claude-opus-4-0":              true,
-	},
-	"Google": {
-		"gemini-3-pro-preview":   true,
-		"gemini-3-flash-preview": true,
-		"gemini-2.5-pro":         true,
-		"gemini-2.5-flash":       true,
-		"gemini-2.5-flash-lite":  true,
-		"gemini-2.0-flash":       true,
-	},
-	"xAI": {
-		"grok-4":           true,
-		"grok-4.1-fast":    true,
-		"grok-4-fast":      true,
-		"grok-code-fast-1": true,
-		"grok-3":           true,
-		"grok-3-mini":      true,
-	},
-	"Mistral": {
-		"mistral-large-2512":  true,
-		"mistral-medium-2505": true,
-		"mistral-small-2506":  true,
-		"devstral-2512":       true,
-		"devstral-small-2512": true,
-		"codestral-2508":      true,
-	},
-	"DeepSeek": {
-		"deepseek-reasoner": true,
-		"deepseek-chat":     true,
-		"deepseek-r1":       true,
-		"deepseek-v3":       true,
-	},
-	"Meta": {
-		"llama-4-maverick": true,
-		"llama-4-scout":    true,
-		"llama-3.3-70b":    true,
-	},
-	"Amazon": {
-		"amazon-nova-micro":     true,
-		"amazon-nova-lite":      true,
-		"amazon-nova-pro":       true,
-		"amazon-nova-premier":   true,
-		"amazon-nova-2-lite":    true,
-		"amazon-nova-2-pro":     true,
-	},
-	"Cohere": {
-		"command-a-03-2025":            true,
-		"command-a-reasoning-08-2025":  true,
-		"command-a-vision-07-2025":     true,
-		"command-r7b-12-2024":          true,
-	},
-	"Perplexity": {
-		"sonar":                true,
-		"sonar-pro":            true,
-		"sonar-reasoning-pro":  true,
-	},
-	"AI21": {
-		"jamba-large-1.7": true,
-		"jamba-mini-1.7":  true,
-	},
+// loadFromRegistry converts a *registry.Registry into the shapes the rest
+// of the updater works with: a URL/auth map for fetching, a tracked-ID set
+// per provider, and a deterministic fetch order (only providers with a URL,
+// sorted by name, since only those support fetchModelsWithRetry).
+func loadFromRegistry(reg *registry.Registry) (map[string]Provider, map[string]map[string]bool, []string) {
+	providers := make(map[string]Provider, len(reg.Providers))
+	var order []string
+	for _, name := range reg.ProviderOrder() {
+		p := reg.Providers[name]
+		if p.URL != "" {
+			providers[name] = Provider{
+				URL:                p.URL,
+				AuthEnv:            p.AuthEnv,
+				AuthHeader:         p.AuthHeader,
+				Schedule:           p.Schedule,
+				RateLimitPerMinute: p.RateLimitPerMinute,
+			}
+			order = append(order, name)
+		}
+	}
+	return providers, reg.KnownModels(), order
 }
 
+// configFlag and registryURLFlag register --config/--registry-url so
+// `updater --help` documents them; the actual values are read earlier by
+// registryConfigPath/registryURL so providers/knownModels are ready
+// before main() runs. When both are set, --registry-url takes priority.
+var configFlag = flag.String("config", defaultRegistryPath, "path to the registry YAML/JSON file (also settable via REGISTRY_CONFIG)")
+var registryURLFlag = flag.String("registry-url", "", "URL to fetch the registry YAML/JSON from instead of a local file (also settable via REGISTRY_URL)")
+
+// The --report-* flags write the same run's DiffReport (see report.go) in
+// the given format, in addition to the human-readable log this package
+// has always printed; a flag left empty skips that format. --last-seen
+// controls where FirstSeen is persisted between runs.
+var (
+	reportJSONFlag     = flag.String("report-json", "", "write a DiffReport as JSON to this path")
+	reportMarkdownFlag = flag.String("report-markdown", "", "write a DiffReport as a Markdown table to this path")
+	reportDiffFlag     = flag.String("report-diff", "", "write a DiffReport as a unified-diff-style patch to this path")
+	lastSeenPathFlag   = flag.String("last-seen", "last_seen.json", "path to the FirstSeen persistence file")
+)
+
 // apiResponse is the common shape returned by OpenAI-compatible model list APIs.
 type apiResponse struct {
 	Data   []apiModel `json:"data"`
@@ -134,13 +186,24 @@ type apiModel struct {
 
 const maxRetries = 3
 
+var serveFlag = flag.Bool("serve", false, "run as a long-lived daemon that schedules checks per provider instead of checking once and exiting")
+
 func main() {
+	flag.Parse()
+
+	if *serveFlag {
+		if err := runServe(context.Background()); err != nil {
+			fmt.Printf("[Daemon] %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	client := &http.Client{Timeout: 30 * time.Second}
 	ctx := context.Background()
 
 	hasChanges := false
 	hasErrors := false
-	providerOrder := []string{"OpenAI", "Anthropic", "Google", "Mistral", "xAI", "DeepSeek"}
 
 	// Capture report output for GitHub issue creation.
 	var report strings.Builder
@@ -148,6 +211,14 @@ func main() {
 	var allMissing []string
 	// Collect all new model IDs for issue reporting.
 	var allNew []string
+	// Collect each provider's DiffReport for the --report-* flags.
+	var allReports []DiffReport
+
+	seen, err := loadLastSeen(*lastSeenPathFlag)
+	if err != nil {
+		fmt.Printf("[Report] failed to load %s: %v — FirstSeen starts fresh\n", *lastSeenPathFlag, err)
+		seen = map[string]time.Time{}
+	}
 
 	logf := func(format string, args ...any) {
 		line := fmt.Sprintf(format, args...)
@@ -166,15 +237,17 @@ func main() {
 			continue
 		}
 
-		ids, err := fetchModelsWithRetry(ctx, client, name, p, key)
+		ids, _, err := fetchModelsWithRetry(ctx, client, name, p, key)
 		if err != nil {
 			logf("[%s] ERROR: %v\n", name, err)
 			hasErrors = true
+			notifyReport(ctx, notify.Report{Provider: name, Errors: []string{err.Error()}})
 			continue
 		}
 
 		known := knownModels[name]
-		newModels, missing := diff(known, ids)
+		newModels, missing := diffProvider(name, known, ids)
+		allReports = append(allReports, DiffDetailed(name, known, ids, seen))
 
 		logf("[%s] API returned %d models, we track %d\n", name, len(ids), len(known))
 
@@ -198,18 +271,22 @@ func main() {
 		}
 		if len(newModels) == 0 && len(missing) == 0 {
 			logf("  OK: in sync\n")
+		} else {
+			notifyReport(ctx, notify.Report{Provider: name, NewIDs: newModels, MissingIDs: missing})
 		}
 		logf("\n")
 	}
 
-	// Providers without direct model-listing APIs — just note them.
-	logf("[Meta] SKIP: no direct API (models are provider-hosted)\n")
-	logf("[Amazon] SKIP: no public model-listing API (check AWS Bedrock console)\n")
-	logf("[Cohere] SKIP: no public model-listing API (check docs.cohere.com)\n")
-	logf("[Perplexity] SKIP: no public model-listing API (check docs.perplexity.ai)\n")
-	logf("[AI21] SKIP: no public model-listing API (check docs.ai21.com)\n")
+	// Providers tracked in the registry but without a URL configured have
+	// no direct model-listing API — just note them.
+	for name := range knownModels {
+		if _, hasURL := providers[name]; !hasURL {
+			logf("[%s] SKIP: no model-listing API configured in registry\n", name)
+		}
+	}
 
 	logf("\n=== Summary ===\n")
+	exitCode := 0
 	if hasChanges {
 		if hasErrors {
 			logf("WARNING: Some providers failed to respond (see errors above).\n")
@@ -217,37 +294,70 @@ func main() {
 		logf("Changes detected. Review the output above.\n")
 		// Auto-deprecate missing models via PR (fully automatic).
 		if len(allMissing) > 0 {
-			createDeprecationPR(ctx, client, allMissing, report.String())
+			createDeprecationPR(ctx, allMissing, report.String())
 		}
 		// New models need human review — create an issue.
 		if len(allNew) > 0 {
-			createGitHubIssue(ctx, client, report.String())
+			createGitHubIssue(ctx, report.String())
 		}
-		os.Exit(1)
+		exitCode = 1
 	} else if hasErrors {
 		logf("No model changes detected, but some providers could not be checked.\n")
-		os.Exit(1)
+		exitCode = 1
+	} else {
+		logf("All tracked providers are in sync.\n")
+	}
+
+	writeReports(mergeReports(allReports))
+	if err := saveLastSeen(*lastSeenPathFlag, seen); err != nil {
+		fmt.Printf("[Report] failed to save %s: %v\n", *lastSeenPathFlag, err)
+	}
+
+	runCleanup(ctx)
+	os.Exit(exitCode)
+}
+
+// writeReports writes r to whichever of --report-json/--report-markdown/
+// --report-diff were given a path; any left empty are skipped.
+func writeReports(r DiffReport) {
+	if path := *reportJSONFlag; path != "" {
+		data, err := r.ToJSON()
+		if err == nil {
+			err = os.WriteFile(path, data, 0o644)
+		}
+		if err != nil {
+			fmt.Printf("[Report] failed to write %s: %v\n", path, err)
+		}
+	}
+	if path := *reportMarkdownFlag; path != "" {
+		if err := os.WriteFile(path, []byte(r.ToMarkdownTable()), 0o644); err != nil {
+			fmt.Printf("[Report] failed to write %s: %v\n", path, err)
+		}
+	}
+	if path := *reportDiffFlag; path != "" {
+		if err := os.WriteFile(path, []byte(r.ToUnifiedDiff()), 0o644); err != nil {
+			fmt.Printf("[Report] failed to write %s: %v\n", path, err)
+		}
 	}
-	logf("All tracked providers are in sync.\n")
-	os.Exit(0)
 }
 
-// fetchModelsWithRetry wraps fetchModels with retry logic for transient failures.
-func fetchModelsWithRetry(ctx context.Context, client *http.Client, name string, p Provider, key string) ([]string, error) {
+// fetchModelsWithRetry wraps fetchModels with retry logic for transient
+// failures. The returned attempts count (>=1) lets callers track retries
+// spent, e.g. for the daemon metrics in metrics.go.
+func fetchModelsWithRetry(ctx context.Context, client *http.Client, name string, p Provider, key string) (ids []string, attempts int, err error) {
 	var lastErr error
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		ids, err := fetchModels(ctx, client, name, p, key)
-		if err == nil {
-			return ids, nil
+		ids, lastErr = fetchModels(ctx, client, name, p, key)
+		if lastErr == nil {
+			return ids, attempt, nil
 		}
-		lastErr = err
 		if attempt < maxRetries {
 			backoff := time.Duration(attempt) * 2 * time.Second
-			fmt.Printf("[%s] attempt %d/%d failed: %v (retrying in %s)\n", name, attempt, maxRetries, err, backoff)
+			fmt.Printf("[%s] attempt %d/%d failed: %v (retrying in %s)\n", name, attempt, maxRetries, lastErr, backoff)
 			time.Sleep(backoff)
 		}
 	}
-	return nil, fmt.Errorf("all %d attempts failed: %w", maxRetries, lastErr)
+	return nil, maxRetries, fmt.Errorf("all %d attempts failed: %w", maxRetries, lastErr)
 }
 
 // fetchModels queries a provider's model listing endpoint and returns model IDs.
@@ -316,285 +426,180 @@ func fetchModels(ctx context.Context, client *http.Client, name string, p Provid
 	return ids, nil
 }
 
-// createGitHubIssue creates a GitHub issue with the update report.
-// Requires GITHUB_TOKEN and GITHUB_REPO (e.g. "owner/repo") environment variables.
-// If either is unset, it silently skips (allowing standalone CLI usage).
-func createGitHubIssue(ctx context.Context, client *http.Client, reportBody string) {
-	token := os.Getenv("GITHUB_TOKEN")
-	repo := os.Getenv("GITHUB_REPO")
-	if token == "" || repo == "" {
-		return
-	}
-
-	today := time.Now().Format("2006-01-02")
-	title := "Model Update Detected - " + today
-
-	// Check for existing open issue with the same title to avoid duplicates.
-	searchURL := fmt.Sprintf("https://api.github.com/search/issues?q=%s+repo:%s+state:open+label:auto-update",
-		strings.ReplaceAll(title, " ", "+"), repo)
-	searchReq, err := http.NewRequestWithContext(ctx, http.MethodGet, searchURL, nil)
-	if err != nil {
-		fmt.Printf("[GitHub] failed to create search request: %v\n", err)
-		return
+// notifyReport fans r out to the configured notification sinks (Slack,
+// Discord, webhook, email), so a Slack outage can't hold up the GitHub
+// issue/PR path below, which is invoked separately after the whole loop.
+func notifyReport(ctx context.Context, r notify.Report) {
+	for _, err := range dispatcher.Dispatch(ctx, r) {
+		fmt.Printf("[Notify] %v\n", err)
 	}
-	searchReq.Header.Set("Authorization", "Bearer "+token)
-	searchReq.Header.Set("Accept", "application/vnd.github+json")
+}
 
-	searchResp, err := client.Do(searchReq)
+// forgeFromEnv builds a forge.Forge from FORGE_KIND / FORGE_BASE_URL /
+// FORGE_REPO / FORGE_TOKEN, falling back to GITHUB_REPO / GITHUB_TOKEN for
+// backwards compatibility with existing GitHub-only deployments. Returns
+// nil if no repo/token is configured, which callers treat as "skip" to
+// preserve standalone CLI usage.
+func forgeFromEnv() forge.Forge {
+	repo := firstNonEmpty(os.Getenv("FORGE_REPO"), os.Getenv("GITHUB_REPO"))
+	token := firstNonEmpty(os.Getenv("FORGE_TOKEN"), os.Getenv("GITHUB_TOKEN"))
+	if repo == "" || token == "" {
+		return nil
+	}
+
+	f, err := forge.New(forge.Config{
+		Kind:    os.Getenv("FORGE_KIND"),
+		BaseURL: os.Getenv("FORGE_BASE_URL"),
+		Repo:    repo,
+		Token:   token,
+	})
 	if err != nil {
-		fmt.Printf("[GitHub] failed to search issues: %v\n", err)
-		return
+		fmt.Printf("[Forge] %v\n", err)
+		return nil
 	}
-	defer searchResp.Body.Close()
+	return f
+}
 
-	if searchResp.StatusCode == http.StatusOK {
-		var searchResult struct {
-			TotalCount int `json:"total_count"`
-		}
-		if err := json.NewDecoder(searchResp.Body).Decode(&searchResult); err == nil && searchResult.TotalCount > 0 {
-			fmt.Printf("[GitHub] Issue already exists for today, skipping.\n")
-			return
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
 		}
 	}
+	return ""
+}
 
-	// Create the issue.
-	issueURL := fmt.Sprintf("https://api.github.com/repos/%s/issues", repo)
-	body := map[string]any{
-		"title":  title,
-		"body":   "```\n" + reportBody + "\n```",
-		"labels": []string{"auto-update"},
-	}
-	bodyJSON, err := json.Marshal(body)
-	if err != nil {
-		fmt.Printf("[GitHub] failed to marshal issue body: %v\n", err)
-		return
-	}
-
-	issueReq, err := http.NewRequestWithContext(ctx, http.MethodPost, issueURL, bytes.NewReader(bodyJSON))
-	if err != nil {
-		fmt.Printf("[GitHub] failed to create issue request: %v\n", err)
-		return
-	}
-	issueReq.Header.Set("Authorization", "Bearer "+token)
-	issueReq.Header.Set("Accept", "application/vnd.github+json")
-	issueReq.Header.Set("Content-Type", "application/json")
-
-	issueResp, err := client.Do(issueReq)
-	if err != nil {
-		fmt.Printf("[GitHub] failed to create issue: %v\n", err)
-		return
-	}
-	defer issueResp.Body.Close()
-
-	if issueResp.StatusCode == http.StatusCreated {
-		var created struct {
-			HTMLURL string `json:"html_url"`
-		}
-		json.NewDecoder(issueResp.Body).Decode(&created)
-		fmt.Printf("[GitHub] Issue created: %s\n", created.HTMLURL)
-	} else {
-		respBody, _ := io.ReadAll(io.LimitReader(issueResp.Body, 512))
-		fmt.Printf("[GitHub] Failed to create issue (HTTP %d): %s\n", issueResp.StatusCode, string(respBody))
-	}
+// weekKey identifies the ISO week t falls in, e.g. "2026-W30". Reports
+// from the same week are consolidated into one rolling issue instead of
+// one issue per day.
+func weekKey(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
 }
 
-// createDeprecationPR creates a GitHub PR that changes the status of missing models
-// to "deprecated" in data.go. Uses the GitHub Contents API — no git clone needed.
-// Requires GITHUB_TOKEN and GITHUB_REPO environment variables.
-func createDeprecationPR(ctx context.Context, client *http.Client, missingIDs []string, reportBody string) {
-	token := os.Getenv("GITHUB_TOKEN")
-	repo := os.Getenv("GITHUB_REPO")
-	if token == "" || repo == "" {
+// createGitHubIssue reports reportBody via the configured forge. Reports
+// from the current ISO week are folded into a single rolling issue (found
+// by title) by editing its body instead of opening a new one each day; the
+// cleanup pass (cleanup.go) closes out prior weeks' issues once they're
+// superseded. If no forge is configured, it silently skips (allowing
+// standalone CLI usage).
+func createGitHubIssue(ctx context.Context, reportBody string) {
+	f := forgeFromEnv()
+	if f == nil {
 		return
 	}
 
-	apiBase := "https://api.github.com"
-	filePath := "go-server/internal/models/data.go"
-	today := time.Now().Format("2006-01-02")
-	branchName := "auto-deprecate-" + today
+	title := "Model Update Report - " + weekKey(time.Now())
+	entry := fmt.Sprintf("### %s\n```\n%s\n```", time.Now().UTC().Format(time.RFC3339), reportBody)
 
-	doReq := func(method, url string, body any) (*http.Response, error) {
-		var reader io.Reader
-		if body != nil {
-			b, err := json.Marshal(body)
-			if err != nil {
-				return nil, err
+	issues, err := f.ListIssues(ctx, "auto-update")
+	if err != nil {
+		fmt.Printf("[Forge] failed to list issues: %v\n", err)
+	} else {
+		for _, existing := range issues {
+			if existing.State != "open" || existing.Title != title {
+				continue
 			}
-			reader = bytes.NewReader(b)
-		}
-		req, err := http.NewRequestWithContext(ctx, method, url, reader)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Accept", "application/vnd.github+json")
-		if body != nil {
-			req.Header.Set("Content-Type", "application/json")
+			if err := f.UpdateIssueBody(ctx, existing.Number, existing.Body+"\n\n---\n\n"+entry); err != nil {
+				fmt.Printf("[Forge] failed to update rolling issue: %v\n", err)
+				return
+			}
+			fmt.Printf("[Forge] Updated rolling issue: %s\n", existing.URL)
+			return
 		}
-		return client.Do(req)
 	}
 
-	// Step 1: Get current data.go content and blob SHA.
-	fileURL := fmt.Sprintf("%s/repos/%s/contents/%s", apiBase, repo, filePath)
-	resp, err := doReq(http.MethodGet, fileURL, nil)
+	issue, err := f.OpenIssue(ctx, title, entry, []string{"auto-update"})
 	if err != nil {
-		fmt.Printf("[GitHub PR] failed to get file: %v\n", err)
-		return
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		fmt.Printf("[GitHub PR] failed to get file: HTTP %d\n", resp.StatusCode)
+		fmt.Printf("[Forge] failed to create issue: %v\n", err)
 		return
 	}
+	fmt.Printf("[Forge] Issue created: %s\n", issue.URL)
+}
 
-	var fileInfo struct {
-		SHA     string `json:"sha"`
-		Content string `json:"content"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&fileInfo); err != nil {
-		fmt.Printf("[GitHub PR] failed to decode file info: %v\n", err)
+// createDeprecationPR opens a PR via the configured forge that changes the
+// status of missing models to "deprecated" in data.go.
+func createDeprecationPR(ctx context.Context, missingIDs []string, reportBody string) {
+	f := forgeFromEnv()
+	if f == nil {
 		return
 	}
 
-	// Decode base64 content (GitHub inserts newlines in base64).
-	rawContent, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(fileInfo.Content, "\n", ""))
+	const filePath = "go-server/internal/models/data.go"
+	const baseBranch = "main"
+	today := time.Now().Format("2006-01-02")
+	branchName := "auto-deprecate-" + today
+
+	file, err := f.GetFile(ctx, filePath)
 	if err != nil {
-		fmt.Printf("[GitHub PR] failed to decode file content: %v\n", err)
+		fmt.Printf("[Forge] failed to get file: %v\n", err)
 		return
 	}
 
-	// Step 2: Apply deprecation changes to the file content.
-	content := string(rawContent)
-	changed := false
+	deprecations := make(map[string]datapatch.Deprecation, len(missingIDs))
 	for _, id := range missingIDs {
-		// Match the Status line for this model's block. The pattern matches:
-		//   "model-id": {  ...  Status: "current",  or  Status: "legacy",
-		// and replaces with Status: "deprecated".
-		// We use a targeted regex that finds the model block by ID.
-		pattern := fmt.Sprintf(`("%s":\s*\{[^}]*Status:\s*)"(?:current|legacy)"`, regexp.QuoteMeta(id))
-		re := regexp.MustCompile(pattern)
-		if re.MatchString(content) {
-			content = re.ReplaceAllString(content, `${1}"deprecated"`)
-			changed = true
-			fmt.Printf("[GitHub PR] Marking %s as deprecated\n", id)
-		}
-	}
-
-	if !changed {
-		fmt.Printf("[GitHub PR] No status changes needed in data.go\n")
-		return
+		deprecations[id] = datapatch.Deprecation{DeprecatedOn: today, Replacement: modelMeta[id].Replacement}
 	}
 
-	// Step 3: Get main branch SHA to create branch from.
-	refURL := fmt.Sprintf("%s/repos/%s/git/ref/heads/main", apiBase, repo)
-	resp, err = doReq(http.MethodGet, refURL, nil)
+	newContent, changed, err := datapatch.ApplyDeprecations([]byte(file.Content), deprecations)
 	if err != nil {
-		fmt.Printf("[GitHub PR] failed to get main ref: %v\n", err)
+		fmt.Printf("[Forge] failed to patch data.go: %v\n", err)
 		return
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		fmt.Printf("[GitHub PR] failed to get main ref: HTTP %d\n", resp.StatusCode)
-		return
+	for _, id := range changed {
+		fmt.Printf("[Forge] Marking %s as deprecated\n", id)
 	}
-
-	var refInfo struct {
-		Object struct {
-			SHA string `json:"sha"`
-		} `json:"object"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&refInfo); err != nil {
-		fmt.Printf("[GitHub PR] failed to decode ref info: %v\n", err)
+	if len(changed) == 0 {
+		fmt.Printf("[Forge] No status changes needed in data.go\n")
 		return
 	}
 
-	// Step 4: Create new branch.
-	createRefURL := fmt.Sprintf("%s/repos/%s/git/refs", apiBase, repo)
-	resp, err = doReq(http.MethodPost, createRefURL, map[string]string{
-		"ref": "refs/heads/" + branchName,
-		"sha": refInfo.Object.SHA,
-	})
-	if err != nil {
-		fmt.Printf("[GitHub PR] failed to create branch: %v\n", err)
-		return
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		fmt.Printf("[GitHub PR] failed to create branch (HTTP %d): %s\n", resp.StatusCode, string(body))
+	if err := f.CreateBranch(ctx, branchName, baseBranch); err != nil {
+		fmt.Printf("[Forge] failed to create branch: %v\n", err)
 		return
 	}
 
-	// Step 5: Update file on new branch.
 	sort.Strings(missingIDs)
 	commitMsg := fmt.Sprintf("auto: deprecate %s (removed from provider API)", strings.Join(missingIDs, ", "))
-	resp, err = doReq(http.MethodPut, fileURL, map[string]string{
-		"message": commitMsg,
-		"content": base64.StdEncoding.EncodeToString([]byte(content)),
-		"sha":     fileInfo.SHA,
-		"branch":  branchName,
-	})
-	if err != nil {
-		fmt.Printf("[GitHub PR] failed to update file: %v\n", err)
-		return
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		fmt.Printf("[GitHub PR] failed to update file (HTTP %d): %s\n", resp.StatusCode, string(body))
+	if err := f.PutFile(ctx, filePath, branchName, file.SHA, string(newContent), commitMsg); err != nil {
+		fmt.Printf("[Forge] failed to update file: %v\n", err)
 		return
 	}
 
-	// Step 6: Create pull request.
-	prURL := fmt.Sprintf("%s/repos/%s/pulls", apiBase, repo)
-	prBody := fmt.Sprintf("## Auto-Deprecation\n\nModels removed from provider APIs:\n")
+	prBody := "## Auto-Deprecation\n\nModels removed from provider APIs:\n"
 	for _, id := range missingIDs {
 		prBody += fmt.Sprintf("- `%s`\n", id)
 	}
 	prBody += fmt.Sprintf("\n<details>\n<summary>Full update report</summary>\n\n```\n%s\n```\n</details>", reportBody)
 
-	resp, err = doReq(http.MethodPost, prURL, map[string]any{
-		"title": "auto: deprecate models removed from provider APIs — " + today,
-		"body":  prBody,
-		"head":  branchName,
-		"base":  "main",
-	})
+	pr, err := f.OpenPullRequest(ctx, "auto: deprecate models removed from provider APIs — "+today, prBody, branchName, baseBranch)
 	if err != nil {
-		fmt.Printf("[GitHub PR] failed to create PR: %v\n", err)
+		fmt.Printf("[Forge] failed to create PR: %v\n", err)
 		return
 	}
-	defer resp.Body.Close()
+	fmt.Printf("[Forge] Created: %s\n", pr.URL)
 
-	if resp.StatusCode == http.StatusCreated {
-		var pr struct {
-			HTMLURL string `json:"html_url"`
-			Number  int    `json:"number"`
-		}
-		json.NewDecoder(resp.Body).Decode(&pr)
-		fmt.Printf("[GitHub PR] Created: %s\n", pr.HTMLURL)
-		// Add auto-update label to the PR for auto-merge workflow.
-		labelURL := fmt.Sprintf("%s/repos/%s/issues/%d/labels", apiBase, repo, pr.Number)
-		resp, err = doReq(http.MethodPost, labelURL, []string{"auto-update"})
-		if err == nil {
-			defer resp.Body.Close()
-		}
-	} else {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		fmt.Printf("[GitHub PR] Failed to create PR (HTTP %d): %s\n", resp.StatusCode, string(body))
+	// Add auto-update label to the PR for auto-merge workflow.
+	if err := f.AddLabels(ctx, pr.Number, []string{"auto-update"}); err != nil {
+		fmt.Printf("[Forge] failed to label PR: %v\n", err)
 	}
 }
 
 // diff compares our known models against API results.
 // Returns new models (in API but not known) and missing models (known but not in API).
+//
+// An API ID that is a Version/Modifiers variant of a known ID (a date
+// stamp, a "-latest"/"-preview" release channel, a YYMM revision, ...)
+// is not reported as new; see ParseModelID and isKnownAlias.
 func diff(known map[string]bool, apiIDs []string) (newModels, missing []string) {
 	apiSet := make(map[string]bool, len(apiIDs))
 	for _, id := range apiIDs {
 		apiSet[id] = true
 	}
 
-	// New: in API but not in our registry
+	// New: in API but not in our registry, and not a known alias of it
 	for _, id := range apiIDs {
-		if !known[id] {
+		if !known[id] && !isKnownAlias(id, known) {
 			newModels = append(newModels, id)
 		}
 	}
@@ -608,3 +613,37 @@ func diff(known map[string]bool, apiIDs []string) (newModels, missing []string)
 
 	return newModels, missing
 }
+
+// diffProvider is diff's provider-aware entry point: if name has a
+// registered internal/providers.Provider, its Classify is used in place
+// of isKnownAlias, so each provider's own naming rules (date stamps,
+// release-channel aliases, numeric revisions, ...) decide what counts as
+// new. Registry providers without a bespoke registration get
+// providers.Build's generic classifier; only a name not registered at
+// all falls back to diff's own logic unchanged.
+func diffProvider(name string, known map[string]bool, apiIDs []string) (newModels, missing []string) {
+	p, ok := providerreg.Get(name)
+	if !ok {
+		return diff(known, apiIDs)
+	}
+
+	apiSet := make(map[string]bool, len(apiIDs))
+	for _, raw := range apiIDs {
+		apiSet[p.Canonicalize(raw)] = true
+	}
+
+	for _, id := range apiIDs {
+		id = p.Canonicalize(id)
+		if !p.Classify(id, known).IsVariant() {
+			newModels = append(newModels, id)
+		}
+	}
+
+	for id := range known {
+		if !apiSet[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return newModels, missing
+}
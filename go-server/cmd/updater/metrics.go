@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// daemonMetrics tracks per-provider counters for the long-running --serve
+// mode, exposed on /metrics in Prometheus text format. Hand-rolled for the
+// same reason go-server/cmd/server/metrics.go is: a handful of series
+// don't need a client library.
+type daemonMetrics struct {
+	mu           sync.Mutex
+	newTotal     map[string]int64
+	missingTotal map[string]int64
+	errorsTotal  map[string]int64
+	retriesTotal map[string]int64
+	lastSuccess  map[string]time.Time
+}
+
+func newDaemonMetrics() *daemonMetrics {
+	return &daemonMetrics{
+		newTotal:     make(map[string]int64),
+		missingTotal: make(map[string]int64),
+		errorsTotal:  make(map[string]int64),
+		retriesTotal: make(map[string]int64),
+		lastSuccess:  make(map[string]time.Time),
+	}
+}
+
+func (m *daemonMetrics) record(o checkOutcome) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if o.Attempts > 1 {
+		m.retriesTotal[o.Provider] += int64(o.Attempts - 1)
+	}
+	if o.Err != nil {
+		m.errorsTotal[o.Provider]++
+		return
+	}
+	m.newTotal[o.Provider] += int64(len(o.New))
+	m.missingTotal[o.Provider] += int64(len(o.Missing))
+	m.lastSuccess[o.Provider] = time.Now()
+}
+
+func (m *daemonMetrics) writeTo(w http.ResponseWriter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP updater_new_models_total New model IDs observed per provider.")
+	fmt.Fprintln(w, "# TYPE updater_new_models_total counter")
+	for _, p := range sortedKeys(m.newTotal) {
+		fmt.Fprintf(w, "updater_new_models_total{provider=%q} %d\n", p, m.newTotal[p])
+	}
+
+	fmt.Fprintln(w, "# HELP updater_missing_models_total Tracked model IDs no longer returned by the provider API.")
+	fmt.Fprintln(w, "# TYPE updater_missing_models_total counter")
+	for _, p := range sortedKeys(m.missingTotal) {
+		fmt.Fprintf(w, "updater_missing_models_total{provider=%q} %d\n", p, m.missingTotal[p])
+	}
+
+	fmt.Fprintln(w, "# HELP updater_errors_total Failed checks per provider (after retries).")
+	fmt.Fprintln(w, "# TYPE updater_errors_total counter")
+	for _, p := range sortedKeys(m.errorsTotal) {
+		fmt.Fprintf(w, "updater_errors_total{provider=%q} %d\n", p, m.errorsTotal[p])
+	}
+
+	fmt.Fprintln(w, "# HELP updater_retries_total Retry attempts spent per provider.")
+	fmt.Fprintln(w, "# TYPE updater_retries_total counter")
+	for _, p := range sortedKeys(m.retriesTotal) {
+		fmt.Fprintf(w, "updater_retries_total{provider=%q} %d\n", p, m.retriesTotal[p])
+	}
+
+	fmt.Fprintln(w, "# HELP updater_last_success_timestamp_seconds Unix time of the last successful check per provider.")
+	fmt.Fprintln(w, "# TYPE updater_last_success_timestamp_seconds gauge")
+	for _, p := range sortedKeys(m.lastSuccess) {
+		fmt.Fprintf(w, "updater_last_success_timestamp_seconds{provider=%q} %d\n", p, m.lastSuccess[p].Unix())
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,133 @@
+package main
+
+import "strings"
+
+// ModelID is a structured breakdown of a provider's model identifier into
+// the parts that matter when deciding whether two IDs name "the same"
+// underlying model: Family and Size are the parts a new release keeps,
+// Version and Modifiers are the parts that change release to release.
+//
+// This replaces the old isKnownAlias heuristics (a hardcoded alias suffix
+// list plus a magic "≥4-digit numeric suffix" rule) with a single parse
+// step that both diff() and any future comparison can share.
+type ModelID struct {
+	Raw       string
+	Family    string
+	Size      string
+	Version   string
+	Modifiers []string
+}
+
+// sizeTokens are the known size qualifiers that separate Family from the
+// rest of an ID, e.g. "gpt-5-mini" has Family "gpt-5", Size "mini".
+var sizeTokens = map[string]bool{
+	"mini": true, "nano": true, "small": true, "medium": true,
+	"large": true, "pro": true, "ultra": true,
+}
+
+// modifierTokens are trailing words describing a release channel or
+// capability rather than a distinct model, e.g. "-latest", "-preview".
+// "non" is included so "non-reasoning" (split on "-") is peeled off as a
+// pair rather than left stuck to Family.
+var modifierTokens = map[string]bool{
+	"latest": true, "beta": true, "preview": true, "chat": true,
+	"reasoning": true, "non": true,
+}
+
+// ParseModelID splits a provider model ID into Family/Size/Version/Modifiers.
+//
+// Tokens (split on "-") are consumed from the right: trailing modifier
+// words are peeled off first, then a trailing date (YYYY-MM-DD or
+// YYYYMMDD) or a bare numeric revision (4+ digits, e.g. "2508") is taken
+// as Version, then a known size word, with whatever remains joined back
+// into Family.
+func ParseModelID(raw string) ModelID {
+	tokens := strings.Split(raw, "-")
+	id := ModelID{Raw: raw}
+
+	end := len(tokens)
+	for end > 1 && modifierTokens[tokens[end-1]] {
+		id.Modifiers = append([]string{tokens[end-1]}, id.Modifiers...)
+		end--
+	}
+
+	if end >= 3 && isDateTriple(tokens[end-3], tokens[end-2], tokens[end-1]) {
+		id.Version = strings.Join(tokens[end-3:end], "-")
+		end -= 3
+	} else if end >= 2 && isAllDigits(tokens[end-1]) && len(tokens[end-1]) >= 4 {
+		id.Version = tokens[end-1]
+		end--
+	}
+
+	if end >= 2 && sizeTokens[tokens[end-1]] {
+		id.Size = tokens[end-1]
+		end--
+	}
+
+	id.Family = strings.Join(tokens[:end], "-")
+	return id
+}
+
+// isDateTriple reports whether y, m, d together look like a YYYY-MM-DD date.
+func isDateTriple(y, m, d string) bool {
+	return len(y) == 4 && isAllDigits(y) &&
+		len(m) == 2 && isAllDigits(m) &&
+		len(d) == 2 && isAllDigits(d)
+}
+
+// isAllDigits reports whether s is non-empty and consists only of digits.
+func isAllDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Equivalent reports whether a and b name the same underlying model,
+// differing only in Version or Modifiers.
+func (a ModelID) Equivalent(b ModelID) bool {
+	return a.Family == b.Family && a.Size == b.Size
+}
+
+// SatisfiesKnown reports whether id is an exact match, or a Version/
+// Modifiers variant, of some ID already present in known.
+func (id ModelID) SatisfiesKnown(known map[string]bool) bool {
+	if known[id.Raw] {
+		return true
+	}
+	for k := range known {
+		if id.Equivalent(ParseModelID(k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDateStampVariant reports whether id carries an explicit calendar date
+// stamp (YYYYMMDD or YYYY-MM-DD), as opposed to a short YYMM-style
+// revision number.
+func isDateStampVariant(id string) bool {
+	v := ParseModelID(id).Version
+	if v == "" {
+		return false
+	}
+	if strings.Contains(v, "-") {
+		return true
+	}
+	return len(v) == 8
+}
+
+// isKnownAlias reports whether id is a release-channel, date-stamp, or
+// revision variant of some ID already in known — i.e. the same model
+// under ParseModelID's Family/Size grouping, not a genuinely new one.
+func isKnownAlias(id string, known map[string]bool) bool {
+	if known[id] {
+		return false
+	}
+	return ParseModelID(id).SatisfiesKnown(known)
+}
@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestParseModelID_SplitsFamilySizeVersion(t *testing.T) {
+	tests := []struct {
+		id         string
+		wantFamily string
+		wantSize   string
+		wantVer    string
+	}{
+		{"gpt-5-mini-2025", "gpt-5", "mini", "2025"},
+		{"gpt-5", "gpt-5", "", ""},
+		{"codestral-2508", "codestral", "", "2508"},
+		{"mistral-large-2512", "mistral", "large", "2512"},
+		{"gpt-5-audio-preview", "gpt-5-audio", "", ""},
+		{"gpt-5-chat-latest", "gpt-5", "", ""},
+	}
+	for _, tt := range tests {
+		got := ParseModelID(tt.id)
+		if got.Family != tt.wantFamily || got.Size != tt.wantSize || got.Version != tt.wantVer {
+			t.Errorf("ParseModelID(%q) = %+v, want Family=%q Size=%q Version=%q",
+				tt.id, got, tt.wantFamily, tt.wantSize, tt.wantVer)
+		}
+	}
+}
+
+func TestModelID_Equivalent(t *testing.T) {
+	a := ParseModelID("gpt-5-audio-preview")
+	b := ParseModelID("gpt-5-audio")
+	if !a.Equivalent(b) {
+		t.Errorf("expected %q and %q to be equivalent", a.Raw, b.Raw)
+	}
+
+	c := ParseModelID("devstral-2507")
+	d := ParseModelID("codestral-2508")
+	if c.Equivalent(d) {
+		t.Errorf("did not expect %q and %q to be equivalent", c.Raw, d.Raw)
+	}
+}
+
+func TestModelID_SatisfiesKnown(t *testing.T) {
+	known := map[string]bool{"gpt-5-audio": true}
+	if !ParseModelID("gpt-5-audio-preview").SatisfiesKnown(known) {
+		t.Error("expected gpt-5-audio-preview to satisfy known gpt-5-audio")
+	}
+	if ParseModelID("devstral-2507").SatisfiesKnown(known) {
+		t.Error("did not expect devstral-2507 to satisfy an unrelated known set")
+	}
+}
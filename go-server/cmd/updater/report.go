@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	providerreg "go-server/internal/providers"
+)
+
+// DiffStatus classifies a single model ID's place in a DiffReport. Unlike
+// diff()/diffProvider's flattened new/missing slices, every API-reported
+// and every tracked ID gets an entry, with enough detail for downstream
+// tooling to act on it without re-deriving what diff already knows.
+type DiffStatus string
+
+const (
+	StatusNew                    DiffStatus = "New"
+	StatusKnown                  DiffStatus = "Known"
+	StatusFilteredDateStamp      DiffStatus = "FilteredDateStamp"
+	StatusFilteredAlias          DiffStatus = "FilteredAlias"
+	StatusFilteredNumericVariant DiffStatus = "FilteredNumericVariant"
+	StatusMissing                DiffStatus = "Missing"
+)
+
+// DiffEntry is one model ID's outcome within a DiffReport.
+type DiffEntry struct {
+	ID          string     `json:"id"`
+	Provider    string     `json:"provider"`
+	Status      DiffStatus `json:"status"`
+	CanonicalID string     `json:"canonical_id,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	FirstSeen   time.Time  `json:"first_seen,omitempty"`
+}
+
+// DiffReport is diffProvider's structured counterpart, suitable for
+// serializing to CI rather than grepping the human-readable log.
+type DiffReport struct {
+	Entries []DiffEntry `json:"entries"`
+}
+
+// DiffDetailed is diffProvider's structured counterpart: it classifies
+// every apiIDs entry and every known entry the same way diffProvider
+// does (delegating to the registered Provider's Classify when one
+// exists), but returns a full DiffReport instead of just new/missing
+// slices. seen is consulted and updated in place so FirstSeen survives
+// across runs; see loadLastSeen/saveLastSeen.
+func DiffDetailed(name string, known map[string]bool, apiIDs []string, seen map[string]time.Time) DiffReport {
+	p, hasProvider := providerreg.Get(name)
+	now := time.Now().UTC()
+
+	apiSet := make(map[string]bool, len(apiIDs))
+	var entries []DiffEntry
+
+	for _, raw := range apiIDs {
+		id := raw
+		if hasProvider {
+			id = p.Canonicalize(id)
+		}
+		apiSet[id] = true
+
+		if _, ok := seen[id]; !ok {
+			seen[id] = now
+		}
+
+		entry := DiffEntry{ID: id, Provider: name, FirstSeen: seen[id]}
+		switch {
+		case known[id]:
+			entry.Status = StatusKnown
+		case hasProvider:
+			entry.Status, entry.Reason = diffStatusFor(p.Classify(id, known))
+		case isKnownAlias(id, known):
+			entry.Status = StatusFilteredAlias
+			entry.Reason = "alias of a known model (ParseModelID)"
+		default:
+			entry.Status = StatusNew
+		}
+
+		if entry.Status != StatusNew && entry.Status != StatusKnown {
+			if canon, ok := canonicalMatch(id, known); ok {
+				entry.CanonicalID = canon
+				entry.Reason = entry.Reason + " of " + canon
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	for id := range known {
+		if !apiSet[id] {
+			entries = append(entries, DiffEntry{ID: id, Provider: name, Status: StatusMissing})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return DiffReport{Entries: entries}
+}
+
+// diffStatusFor maps a providers.Classification onto the DiffStatus/Reason
+// pair a DiffEntry reports. Deprecated variants (no current provider
+// emits one yet) are folded into FilteredAlias, since a DiffReport has no
+// separate bucket for them.
+func diffStatusFor(c providerreg.Classification) (DiffStatus, string) {
+	switch c {
+	case providerreg.DateVariant:
+		return StatusFilteredDateStamp, "date-stamped snapshot"
+	case providerreg.Alias:
+		return StatusFilteredAlias, "release-channel alias"
+	case providerreg.NumericRevision:
+		return StatusFilteredNumericVariant, "numeric revision"
+	case providerreg.Deprecated:
+		return StatusFilteredAlias, "deprecated variant"
+	default:
+		return StatusNew, ""
+	}
+}
+
+// canonicalMatch finds a tracked ID that id's ParseModelID decomposition
+// considers Equivalent (same Family/Size), for DiffEntry's CanonicalID.
+// Deterministic: picks the lexicographically first match when more than
+// one known ID qualifies.
+func canonicalMatch(id string, known map[string]bool) (string, bool) {
+	parsed := ParseModelID(id)
+	var candidates []string
+	for k := range known {
+		if parsed.Equivalent(ParseModelID(k)) {
+			candidates = append(candidates, k)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.Strings(candidates)
+	return candidates[0], true
+}
+
+// mergeReports flattens one DiffReport per provider into a single report,
+// sorted by provider then ID, for the --report-* flags' combined output.
+func mergeReports(reports []DiffReport) DiffReport {
+	var entries []DiffEntry
+	for _, r := range reports {
+		entries = append(entries, r.Entries...)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Provider != entries[j].Provider {
+			return entries[i].Provider < entries[j].Provider
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return DiffReport{Entries: entries}
+}
+
+// ToJSON serializes r as indented JSON.
+func (r DiffReport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// ToMarkdownTable renders r as a GitHub-flavored Markdown table, suitable
+// for a PR or issue body.
+func (r DiffReport) ToMarkdownTable() string {
+	var b strings.Builder
+	b.WriteString("| Provider | ID | Status | Canonical ID | Reason |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, e := range r.Entries {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			e.Provider, e.ID, e.Status, orDash(e.CanonicalID), orDash(e.Reason))
+	}
+	return b.String()
+}
+
+// ToUnifiedDiff renders r as a unified-diff-style text patch against the
+// registry: "+" for IDs not yet tracked, "-" for tracked IDs the API no
+// longer returns, " " (context) for everything else, including filtered
+// variants.
+func (r DiffReport) ToUnifiedDiff() string {
+	var b strings.Builder
+	b.WriteString("--- registry\n+++ provider API\n")
+	for _, e := range r.Entries {
+		switch e.Status {
+		case StatusNew:
+			fmt.Fprintf(&b, "+%s\t[%s]\n", e.ID, e.Provider)
+		case StatusMissing:
+			fmt.Fprintf(&b, "-%s\t[%s]\n", e.ID, e.Provider)
+		default:
+			fmt.Fprintf(&b, " %s\t[%s] %s\n", e.ID, e.Provider, e.Status)
+		}
+	}
+	return b.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// loadLastSeen reads the FirstSeen persistence file written by
+// saveLastSeen. A missing file is not an error — it just means nothing
+// has been seen yet.
+func loadLastSeen(path string) (map[string]time.Time, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]time.Time{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var seen map[string]time.Time
+	if err := json.Unmarshal(data, &seen); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return seen, nil
+}
+
+// saveLastSeen persists seen so FirstSeen survives across runs.
+func saveLastSeen(path string, seen map[string]time.Time) error {
+	data, err := json.MarshalIndent(seen, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
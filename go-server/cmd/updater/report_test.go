@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffDetailed_ClassifiesViaRegisteredProvider(t *testing.T) {
+	known := map[string]bool{"gpt-4o": true}
+	seen := map[string]time.Time{}
+
+	report := DiffDetailed("OpenAI", known, []string{"gpt-4o", "gpt-4o-latest", "gpt-5"}, seen)
+
+	byID := map[string]DiffEntry{}
+	for _, e := range report.Entries {
+		byID[e.ID] = e
+	}
+
+	if got := byID["gpt-4o"].Status; got != StatusKnown {
+		t.Errorf("gpt-4o status = %v, want Known", got)
+	}
+	if got := byID["gpt-4o-latest"]; got.Status != StatusFilteredAlias || got.CanonicalID != "gpt-4o" {
+		t.Errorf("gpt-4o-latest = %+v, want FilteredAlias of gpt-4o", got)
+	}
+	if got := byID["gpt-5"].Status; got != StatusNew {
+		t.Errorf("gpt-5 status = %v, want New", got)
+	}
+	if seen["gpt-5"].IsZero() {
+		t.Error("expected DiffDetailed to record a FirstSeen timestamp for a newly observed ID")
+	}
+}
+
+func TestDiffDetailed_FallsBackWithoutRegisteredProvider(t *testing.T) {
+	known := map[string]bool{"widget-1": true}
+	seen := map[string]time.Time{}
+
+	report := DiffDetailed("NoSuchProvider", known, []string{"widget-1", "widget-2"}, seen)
+
+	var gotNew bool
+	for _, e := range report.Entries {
+		if e.ID == "widget-2" && e.Status == StatusNew {
+			gotNew = true
+		}
+	}
+	if !gotNew {
+		t.Errorf("expected widget-2 to classify as New via the fallback path, got %+v", report.Entries)
+	}
+}
+
+func TestDiffDetailed_ReportsMissing(t *testing.T) {
+	known := map[string]bool{"gone-model": true}
+	report := DiffDetailed("OpenAI", known, nil, map[string]time.Time{})
+
+	if len(report.Entries) != 1 || report.Entries[0].Status != StatusMissing || report.Entries[0].ID != "gone-model" {
+		t.Errorf("expected a single Missing entry for gone-model, got %+v", report.Entries)
+	}
+}
+
+func TestDiffReport_Writers(t *testing.T) {
+	r := DiffReport{Entries: []DiffEntry{
+		{ID: "gpt-5", Provider: "OpenAI", Status: StatusNew},
+		{ID: "gpt-4", Provider: "OpenAI", Status: StatusMissing},
+	}}
+
+	if _, err := r.ToJSON(); err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	if md := r.ToMarkdownTable(); !containsAll(md, "| Provider | ID | Status", "gpt-5", "gpt-4") {
+		t.Errorf("ToMarkdownTable missing expected content: %q", md)
+	}
+	if diffText := r.ToUnifiedDiff(); !containsAll(diffText, "+gpt-5", "-gpt-4") {
+		t.Errorf("ToUnifiedDiff missing expected +/- lines: %q", diffText)
+	}
+}
+
+func containsAll(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule is either a fixed interval (parsed from a bare Go duration or an
+// "@every <duration>" expression) or a 5-field cron expression, evaluated
+// in UTC. Exactly one of the two is set.
+type schedule struct {
+	interval time.Duration
+	cron     *cronSchedule
+}
+
+// parseSchedule accepts the same "interval:" / "cron expression" forms a
+// registry.yaml Provider.Schedule field can hold.
+func parseSchedule(expr string) (schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return schedule{}, fmt.Errorf("invalid @every duration %q: %w", rest, err)
+		}
+		return schedule{interval: d}, nil
+	}
+	if d, err := time.ParseDuration(expr); err == nil {
+		return schedule{interval: d}, nil
+	}
+	c, err := parseCron(expr)
+	if err != nil {
+		return schedule{}, fmt.Errorf("invalid schedule %q: not a duration or a 5-field cron expression (%w)", expr, err)
+	}
+	return schedule{cron: c}, nil
+}
+
+// next returns the next time strictly after `after` that the schedule fires.
+func (s schedule) next(after time.Time) time.Time {
+	if s.cron != nil {
+		return s.cron.next(after)
+	}
+	return after.Add(s.interval)
+}
+
+// cronField holds the set of allowed values for one cron field; a nil set
+// means "*" (any value).
+type cronField struct {
+	allowed map[int]bool
+}
+
+func (f cronField) matches(v int) bool {
+	return f.allowed == nil || f.allowed[v]
+}
+
+// cronSchedule is a standard 5-field cron expression: minute hour
+// day-of-month month day-of-week. Supported syntax per field: "*", a bare
+// number, a comma-separated list, a "a-b" range, and "*/N" steps. Step
+// ranges ("a-b/N") aren't supported — use an explicit list instead.
+type cronSchedule struct {
+	minute, hour, dom, month, dow cronField
+}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields (minute hour dom month dow), got %d", len(fields))
+	}
+	ranges := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	parsed := make([]cronField, 5)
+	for i, f := range fields {
+		cf, err := parseCronField(f, ranges[i][0], ranges[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("field %d (%q): %w", i+1, f, err)
+		}
+		parsed[i] = cf
+	}
+	return &cronSchedule{minute: parsed[0], hour: parsed[1], dom: parsed[2], month: parsed[3], dow: parsed[4]}, nil
+}
+
+func parseCronField(s string, min, max int) (cronField, error) {
+	if s == "*" {
+		return cronField{}, nil
+	}
+	if rest, ok := strings.CutPrefix(s, "*/"); ok {
+		step, err := strconv.Atoi(rest)
+		if err != nil || step <= 0 {
+			return cronField{}, fmt.Errorf("invalid step %q", s)
+		}
+		allowed := make(map[int]bool)
+		for v := min; v <= max; v += step {
+			allowed[v] = true
+		}
+		return cronField{allowed: allowed}, nil
+	}
+
+	allowed := make(map[int]bool)
+	for _, part := range strings.Split(s, ",") {
+		if lo, hi, ok := strings.Cut(part, "-"); ok {
+			loN, err1 := strconv.Atoi(lo)
+			hiN, err2 := strconv.Atoi(hi)
+			if err1 != nil || err2 != nil || loN > hiN || loN < min || hiN > max {
+				return cronField{}, fmt.Errorf("invalid range %q", part)
+			}
+			for v := loN; v <= hiN; v++ {
+				allowed[v] = true
+			}
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < min || n > max {
+			return cronField{}, fmt.Errorf("invalid value %q", part)
+		}
+		allowed[n] = true
+	}
+	return cronField{allowed: allowed}, nil
+}
+
+func (c *cronSchedule) matches(t time.Time) bool {
+	return c.minute.matches(t.Minute()) &&
+		c.hour.matches(t.Hour()) &&
+		c.dom.matches(t.Day()) &&
+		c.month.matches(int(t.Month())) &&
+		c.dow.matches(int(t.Weekday()))
+}
+
+// maxCronSearchMinutes bounds how far into the future next() will scan
+// before giving up; a correctly formed cron expression matches within a
+// year, so two years is a generous backstop against a pathological one.
+const maxCronSearchMinutes = 2 * 366 * 24 * 60
+
+func (c *cronSchedule) next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute).UTC()
+	for i := 0; i < maxCronSearchMinutes; i++ {
+		if c.matches(t) {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+	return after.Add(24 * time.Hour)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Durations(t *testing.T) {
+	for _, expr := range []string{"15m", "@every 15m"} {
+		s, err := parseSchedule(expr)
+		if err != nil {
+			t.Fatalf("parseSchedule(%q): %v", expr, err)
+		}
+		if s.interval != 15*time.Minute || s.cron != nil {
+			t.Errorf("parseSchedule(%q) = %+v, want a 15m interval", expr, s)
+		}
+	}
+}
+
+func TestParseSchedule_RejectsGarbage(t *testing.T) {
+	if _, err := parseSchedule("not a schedule"); err == nil {
+		t.Error("expected an error for a non-duration, non-cron schedule")
+	}
+}
+
+func TestParseCron_EveryFiveMinutes(t *testing.T) {
+	c, err := parseCron("*/5 * * * *")
+	if err != nil {
+		t.Fatalf("parseCron: %v", err)
+	}
+	base := time.Date(2026, 7, 26, 10, 0, 0, 0, time.UTC)
+	if !c.matches(base) {
+		t.Error("expected */5 to match :00")
+	}
+	if c.matches(base.Add(time.Minute)) {
+		t.Error("expected */5 to not match :01")
+	}
+	if !c.matches(base.Add(5 * time.Minute)) {
+		t.Error("expected */5 to match :05")
+	}
+}
+
+func TestCronSchedule_NextAdvancesToMatchingMinute(t *testing.T) {
+	c, err := parseCron("30 9 * * *")
+	if err != nil {
+		t.Fatalf("parseCron: %v", err)
+	}
+	after := time.Date(2026, 7, 26, 9, 0, 0, 0, time.UTC)
+	next := c.next(after)
+	if next.Hour() != 9 || next.Minute() != 30 {
+		t.Errorf("expected 09:30, got %s", next.Format("15:04"))
+	}
+}
+
+func TestParseCron_RejectsWrongFieldCount(t *testing.T) {
+	if _, err := parseCron("* * *"); err == nil {
+		t.Error("expected an error for a cron expression with too few fields")
+	}
+}
@@ -0,0 +1,154 @@
+// Package datapatch rewrites the Models map literal in
+// go-server/internal/models/data.go to mark model IDs deprecated. It edits
+// the file's AST rather than matching source text with a regex, so it
+// keeps working across formatting changes (multi-line literals, comments,
+// trailing commas) that would silently defeat a text-based patch.
+package datapatch
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// Deprecation describes how to mark one model ID deprecated: DeprecatedOn
+// is required (a date string, typically "YYYY-MM-DD"); Replacement is set
+// only when a successor model ID is known.
+type Deprecation struct {
+	DeprecatedOn string
+	Replacement  string
+}
+
+// ApplyDeprecations parses src as a Go source file, finds the top-level
+// `Models` map literal, and for every model ID present in both the map and
+// deprecations, sets Status to "deprecated" and sets/replaces the
+// DeprecatedOn (and, if given, Replacement) fields. It returns the
+// reformatted source and the subset of requested IDs actually found and
+// changed; IDs in deprecations but absent from the map are omitted from
+// that list rather than treated as an error, mirroring the old patcher's
+// best-effort behavior.
+func ApplyDeprecations(src []byte, deprecations map[string]Deprecation) (out []byte, changed []string, err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "data.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("datapatch: parse: %w", err)
+	}
+
+	entries, err := findModelsMapEntries(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, kv := range entries {
+		id, ok := stringLitValue(kv.Key)
+		if !ok {
+			continue
+		}
+		dep, wanted := deprecations[id]
+		if !wanted {
+			continue
+		}
+		model, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		applyDeprecationToModel(model, dep)
+		changed = append(changed, id)
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, nil, fmt.Errorf("datapatch: render: %w", err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("datapatch: gofmt: %w", err)
+	}
+	return formatted, changed, nil
+}
+
+// findModelsMapEntries locates `var Models = map[string]Model{...}` and
+// returns its key/value pairs.
+func findModelsMapEntries(file *ast.File) ([]*ast.KeyValueExpr, error) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Values) == 0 {
+				continue
+			}
+			isModels := false
+			for _, name := range vs.Names {
+				if name.Name == "Models" {
+					isModels = true
+				}
+			}
+			if !isModels {
+				continue
+			}
+			lit, ok := vs.Values[0].(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			entries := make([]*ast.KeyValueExpr, 0, len(lit.Elts))
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					entries = append(entries, kv)
+				}
+			}
+			return entries, nil
+		}
+	}
+	return nil, fmt.Errorf("datapatch: no top-level var named Models found")
+}
+
+// applyDeprecationToModel sets Status to "deprecated" on a Model composite
+// literal and sets/appends DeprecatedOn and (if given) Replacement.
+func applyDeprecationToModel(model *ast.CompositeLit, dep Deprecation) {
+	setField(model, "Status", stringLit("deprecated"))
+	setField(model, "DeprecatedOn", stringLit(dep.DeprecatedOn))
+	if dep.Replacement != "" {
+		setField(model, "Replacement", stringLit(dep.Replacement))
+	}
+}
+
+// setField updates the value of an existing `name: ...` field in a
+// composite literal, or appends a new one if it isn't present.
+func setField(lit *ast.CompositeLit, name string, value ast.Expr) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || key.Name != name {
+			continue
+		}
+		kv.Value = value
+		return
+	}
+	lit.Elts = append(lit.Elts, &ast.KeyValueExpr{Key: ast.NewIdent(name), Value: value})
+}
+
+func stringLit(s string) *ast.BasicLit {
+	return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(s)}
+}
+
+func stringLitValue(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	v, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return v, true
+}
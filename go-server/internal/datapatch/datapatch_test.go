@@ -0,0 +1,147 @@
+package datapatch
+
+import (
+	"strings"
+	"testing"
+)
+
+const fixtureSingleLine = `package models
+
+type Model struct {
+	Provider      string
+	Family        string
+	Modality      string
+	ContextWindow int
+	Status        string
+	DeprecatedOn  string ` + "`json:\",omitempty\"`" + `
+	Replacement   string ` + "`json:\",omitempty\"`" + `
+}
+
+var Models = map[string]Model{
+	"gpt-5": {Provider: "OpenAI", Family: "gpt-5", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-4": {Provider: "OpenAI", Family: "gpt-4", Modality: "text", ContextWindow: 128000, Status: "current"},
+}
+`
+
+const fixtureMultiLine = `package models
+
+type Model struct {
+	Provider string
+	Status   string
+}
+
+var Models = map[string]Model{
+	"claude-3": {
+		// legacy model, kept around for a while
+		Provider: "Anthropic",
+		Status:   "legacy",
+	},
+}
+`
+
+const fixtureAlreadyHasDeprecatedOn = `package models
+
+type Model struct {
+	Provider     string
+	Status       string
+	DeprecatedOn string
+}
+
+var Models = map[string]Model{
+	"old-model": {Provider: "OpenAI", Status: "current", DeprecatedOn: "2020-01-01"},
+}
+`
+
+func TestApplyDeprecations_SingleLineEntry(t *testing.T) {
+	out, changed, err := ApplyDeprecations([]byte(fixtureSingleLine), map[string]Deprecation{
+		"gpt-4": {DeprecatedOn: "2026-07-26"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyDeprecations: %v", err)
+	}
+	if len(changed) != 1 || changed[0] != "gpt-4" {
+		t.Fatalf("expected only gpt-4 to change, got %v", changed)
+	}
+	src := string(out)
+	if !strings.Contains(src, `"gpt-4": {Provider: "OpenAI", Family: "gpt-4"`) {
+		t.Errorf("expected the gpt-4 entry's other fields to survive untouched:\n%s", src)
+	}
+	if !strings.Contains(src, `Status: "deprecated"`) {
+		t.Errorf("expected Status to be rewritten to deprecated:\n%s", src)
+	}
+	if !strings.Contains(src, `DeprecatedOn: "2026-07-26"`) {
+		t.Errorf("expected a DeprecatedOn field to be added:\n%s", src)
+	}
+	if !strings.Contains(src, `Status: "current"`) {
+		t.Errorf("expected gpt-5 to be left untouched:\n%s", src)
+	}
+}
+
+func TestApplyDeprecations_MultiLineEntryWithComment(t *testing.T) {
+	out, changed, err := ApplyDeprecations([]byte(fixtureMultiLine), map[string]Deprecation{
+		"claude-3": {DeprecatedOn: "2026-07-26", Replacement: "claude-4"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyDeprecations: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("expected 1 change, got %v", changed)
+	}
+	src := string(out)
+	if !strings.Contains(src, "legacy model, kept around for a while") {
+		t.Errorf("expected the comment to survive:\n%s", src)
+	}
+	if !strings.Contains(src, `Status:   "deprecated"`) && !strings.Contains(src, `Status: "deprecated"`) {
+		t.Errorf("expected Status to be rewritten:\n%s", src)
+	}
+	if !strings.Contains(src, `Replacement: "claude-4"`) {
+		t.Errorf("expected a Replacement field to be added:\n%s", src)
+	}
+}
+
+func TestApplyDeprecations_OverwritesExistingDeprecatedOn(t *testing.T) {
+	out, _, err := ApplyDeprecations([]byte(fixtureAlreadyHasDeprecatedOn), map[string]Deprecation{
+		"old-model": {DeprecatedOn: "2026-07-26"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyDeprecations: %v", err)
+	}
+	src := string(out)
+	if strings.Contains(src, "2020-01-01") {
+		t.Errorf("expected the stale DeprecatedOn value to be replaced:\n%s", src)
+	}
+	if !strings.Contains(src, `DeprecatedOn: "2026-07-26"`) {
+		t.Errorf("expected the new DeprecatedOn value:\n%s", src)
+	}
+}
+
+func TestApplyDeprecations_UnknownIDIsOmittedNotError(t *testing.T) {
+	out, changed, err := ApplyDeprecations([]byte(fixtureSingleLine), map[string]Deprecation{
+		"does-not-exist": {DeprecatedOn: "2026-07-26"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyDeprecations: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("expected no changes for an unknown ID, got %v", changed)
+	}
+	if !strings.Contains(string(out), `Status: "current"`) {
+		t.Error("expected the file to be returned unchanged (modulo formatting)")
+	}
+}
+
+func TestApplyDeprecations_RejectsInvalidSource(t *testing.T) {
+	if _, _, err := ApplyDeprecations([]byte("not valid go"), nil); err == nil {
+		t.Error("expected an error for unparseable source")
+	}
+}
+
+func TestApplyDeprecations_ErrorsWithoutModelsVar(t *testing.T) {
+	src := `package models
+
+var NotModels = map[string]int{"a": 1}
+`
+	if _, _, err := ApplyDeprecations([]byte(src), map[string]Deprecation{"a": {DeprecatedOn: "x"}}); err == nil {
+		t.Error("expected an error when no top-level Models var exists")
+	}
+}
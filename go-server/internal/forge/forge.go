@@ -0,0 +1,108 @@
+// Package forge abstracts over the issue/PR automation APIs of the forges
+// the updater can run against: GitHub, Gitea, and Forgejo. The updater
+// talks only to the Forge interface so it doesn't need to know which one
+// it's pointed at.
+package forge
+
+import (
+	"context"
+	"time"
+)
+
+// File is the content and blob SHA of a file read via GetFile, needed to
+// PutFile a new version without clobbering a concurrent edit.
+type File struct {
+	Content string
+	SHA     string
+}
+
+// PullRequest is the result of a successful OpenPullRequest call.
+type PullRequest struct {
+	Number int
+	URL    string
+}
+
+// Issue is the result of a successful OpenIssue call.
+type Issue struct {
+	Number int
+	URL    string
+}
+
+// IssueDetail is the subset of issue fields the cleanup pass and the
+// weekly-consolidation logic need to decide what to close or edit.
+type IssueDetail struct {
+	Number    int
+	Title     string
+	Body      string
+	State     string // "open" or "closed"
+	CreatedAt time.Time
+	URL       string
+}
+
+// Branch is a ref returned by ListBranches.
+type Branch struct {
+	Name string
+	SHA  string
+}
+
+// Forge is the set of operations the updater needs from a forge (GitHub,
+// Gitea, Forgejo, ...) to report model changes, open deprecation PRs, and
+// clean up after itself.
+type Forge interface {
+	// OpenIssue creates an issue with the given title, body, and labels.
+	OpenIssue(ctx context.Context, title, body string, labels []string) (*Issue, error)
+	// ListIssues returns every issue (open and closed) carrying label, for
+	// duplicate detection, weekly consolidation, and the cleanup pass.
+	ListIssues(ctx context.Context, label string) ([]IssueDetail, error)
+	// UpdateIssueBody replaces an issue's body, used to fold a new report
+	// into the current week's rolling issue instead of opening another one.
+	UpdateIssueBody(ctx context.Context, number int, body string) error
+	// CloseIssue closes an issue superseded by a newer rolling report.
+	CloseIssue(ctx context.Context, number int) error
+	// GetFile reads a file's content and blob SHA from the default branch.
+	GetFile(ctx context.Context, path string) (*File, error)
+	// PutFile writes a file's content to branch, given the SHA returned by
+	// the preceding GetFile call.
+	PutFile(ctx context.Context, path, branch, sha, content, commitMessage string) error
+	// CreateBranch creates a new branch named branch pointed at the tip of
+	// base.
+	CreateBranch(ctx context.Context, branch, base string) error
+	// ListBranches lists branches whose name starts with prefix.
+	ListBranches(ctx context.Context, prefix string) ([]Branch, error)
+	// DeleteBranch deletes a branch, used to clean up stale auto-deprecate
+	// branches once their PR has merged or closed.
+	DeleteBranch(ctx context.Context, name string) error
+	// OpenPullRequest opens a PR from head into base.
+	OpenPullRequest(ctx context.Context, title, body, head, base string) (*PullRequest, error)
+	// PullRequestStateForBranch returns "open", "merged", "closed", or
+	// "none" (no PR found) for the most recent PR with head as its branch.
+	PullRequestStateForBranch(ctx context.Context, head string) (string, error)
+	// AddLabels attaches labels to an issue or PR number.
+	AddLabels(ctx context.Context, number int, labels []string) error
+}
+
+// Config selects and configures a Forge implementation.
+type Config struct {
+	Kind    string // "github" (default), "gitea", or "forgejo"
+	BaseURL string // API base URL; defaults to github.com for kind "github"
+	Repo    string // "owner/repo"
+	Token   string
+}
+
+// New builds a Forge from cfg.
+func New(cfg Config) (Forge, error) {
+	switch cfg.Kind {
+	case "", "github":
+		return newGitHubForge(cfg), nil
+	case "gitea", "forgejo":
+		return newGiteaForge(cfg), nil
+	default:
+		return nil, unsupportedKindError(cfg.Kind)
+	}
+}
+
+type unsupportedKindError string
+
+func (e unsupportedKindError) Error() string {
+	return "forge: unsupported kind " + string(e)
+}
@@ -0,0 +1,131 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNew_UnsupportedKind(t *testing.T) {
+	if _, err := New(Config{Kind: "bitbucket"}); err == nil {
+		t.Fatal("expected an error for an unsupported forge kind")
+	}
+}
+
+func TestGitHubForge_OpenIssueUsesBearerAuth(t *testing.T) {
+	var gotAuth, gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"number": 7, "html_url": "https://example.com/issues/7"}`))
+	}))
+	defer ts.Close()
+
+	f := newGitHubForge(Config{BaseURL: ts.URL, Repo: "owner/repo", Token: "tok"})
+	issue, err := f.OpenIssue(context.Background(), "title", "body", []string{"auto-update"})
+	if err != nil {
+		t.Fatalf("OpenIssue: %v", err)
+	}
+	if gotAuth != "Bearer tok" {
+		t.Errorf("expected Bearer auth, got %q", gotAuth)
+	}
+	if gotPath != "/repos/owner/repo/issues" {
+		t.Errorf("unexpected path %q", gotPath)
+	}
+	if issue.Number != 7 {
+		t.Errorf("expected issue number 7, got %d", issue.Number)
+	}
+}
+
+func TestGiteaForge_OpenIssueUsesTokenAuth(t *testing.T) {
+	var gotAuth, gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"number": 3, "html_url": "https://gitea.example.com/owner/repo/issues/3"}`))
+	}))
+	defer ts.Close()
+
+	f := newGiteaForge(Config{BaseURL: ts.URL, Repo: "owner/repo", Token: "tok"})
+	issue, err := f.OpenIssue(context.Background(), "title", "body", []string{"auto-update"})
+	if err != nil {
+		t.Fatalf("OpenIssue: %v", err)
+	}
+	if gotAuth != "token tok" {
+		t.Errorf("expected token auth, got %q", gotAuth)
+	}
+	if gotPath != "/api/v1/repos/owner/repo/issues" {
+		t.Errorf("unexpected path %q", gotPath)
+	}
+	if issue.Number != 3 {
+		t.Errorf("expected issue number 3, got %d", issue.Number)
+	}
+}
+
+func TestGitHubForge_ListBranchesFiltersByPrefix(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"name": "main", "commit": {"sha": "aaa"}},
+			{"name": "auto-deprecate-2026-07-01", "commit": {"sha": "bbb"}},
+			{"name": "auto-deprecate-2026-07-08", "commit": {"sha": "ccc"}}
+		]`))
+	}))
+	defer ts.Close()
+
+	f := newGitHubForge(Config{BaseURL: ts.URL, Repo: "owner/repo", Token: "tok"})
+	branches, err := f.ListBranches(context.Background(), "auto-deprecate-")
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if len(branches) != 2 {
+		t.Fatalf("expected 2 matching branches, got %d", len(branches))
+	}
+}
+
+func TestGitHubForge_PullRequestStateForBranchReportsMerged(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"state": "closed", "merged": true, "merged_at": "2026-07-01T00:00:00Z"}]`))
+	}))
+	defer ts.Close()
+
+	f := newGitHubForge(Config{BaseURL: ts.URL, Repo: "owner/repo", Token: "tok"})
+	state, err := f.PullRequestStateForBranch(context.Background(), "auto-deprecate-2026-07-01")
+	if err != nil {
+		t.Fatalf("PullRequestStateForBranch: %v", err)
+	}
+	if state != "merged" {
+		t.Errorf("expected merged, got %q", state)
+	}
+}
+
+func TestGitHubForge_ListIssuesSkipsPullRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"number": 1, "title": "a real issue", "state": "open"},
+			{"number": 2, "title": "a PR", "state": "open", "pull_request": {}}
+		]`))
+	}))
+	defer ts.Close()
+
+	f := newGitHubForge(Config{BaseURL: ts.URL, Repo: "owner/repo", Token: "tok"})
+	issues, err := f.ListIssues(context.Background(), "auto-update")
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Number != 1 {
+		t.Errorf("expected only the real issue to survive, got %+v", issues)
+	}
+}
+
+func TestNew_SelectsGiteaForForgejoKind(t *testing.T) {
+	f, err := New(Config{Kind: "forgejo", BaseURL: "https://forge.example.com", Repo: "owner/repo", Token: "tok"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, ok := f.(*giteaForge); !ok {
+		t.Errorf("expected forgejo kind to use the Gitea-compatible client, got %T", f)
+	}
+}
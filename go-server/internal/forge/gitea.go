@@ -0,0 +1,296 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// giteaForge implements Forge against the Gitea API, which Forgejo also
+// speaks (Forgejo is a Gitea fork and kept the same endpoint shapes). The
+// main differences from GitHub are the URL layout (/api/v1/repos/... vs
+// /repos/...) and the auth header (`token <PAT>` instead of
+// `Bearer <PAT>`); branch creation and the PR-by-branch lookup also use
+// Gitea's own endpoints and payloads.
+type giteaForge struct {
+	baseURL string
+	repo    string
+	token   string
+	client  *http.Client
+}
+
+func newGiteaForge(cfg Config) *giteaForge {
+	return &giteaForge{baseURL: strings.TrimSuffix(cfg.BaseURL, "/"), repo: cfg.Repo, token: cfg.Token, client: http.DefaultClient}
+}
+
+func (g *giteaForge) doReq(ctx context.Context, method, path string, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+"/api/v1"+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "token "+g.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return g.client.Do(req)
+}
+
+func (g *giteaForge) OpenIssue(ctx context.Context, title, body string, labels []string) (*Issue, error) {
+	resp, err := g.doReq(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/issues", g.repo), map[string]any{
+		"title":  title,
+		"body":   body,
+		"labels": labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, httpError("create issue", resp)
+	}
+	var created struct {
+		Number int    `json:"number"`
+		URL    string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, err
+	}
+	return &Issue{Number: created.Number, URL: created.URL}, nil
+}
+
+func (g *giteaForge) ListIssues(ctx context.Context, label string) ([]IssueDetail, error) {
+	q := url.Values{
+		"state":  {"all"},
+		"type":   {"issues"},
+		"labels": {label},
+		"limit":  {"100"},
+	}
+	resp, err := g.doReq(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/issues?%s", g.repo, q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpError("list issues", resp)
+	}
+	var raw []struct {
+		Number    int       `json:"number"`
+		Title     string    `json:"title"`
+		Body      string    `json:"body"`
+		State     string    `json:"state"`
+		CreatedAt time.Time `json:"created_at"`
+		HTMLURL   string    `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	issues := make([]IssueDetail, 0, len(raw))
+	for _, r := range raw {
+		issues = append(issues, IssueDetail{Number: r.Number, Title: r.Title, Body: r.Body, State: r.State, CreatedAt: r.CreatedAt, URL: r.HTMLURL})
+	}
+	return issues, nil
+}
+
+func (g *giteaForge) UpdateIssueBody(ctx context.Context, number int, body string) error {
+	resp, err := g.doReq(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/issues/%d", g.repo, number), map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return httpError("update issue", resp)
+	}
+	return nil
+}
+
+func (g *giteaForge) CloseIssue(ctx context.Context, number int) error {
+	resp, err := g.doReq(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/issues/%d", g.repo, number), map[string]string{"state": "closed"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return httpError("close issue", resp)
+	}
+	return nil
+}
+
+func (g *giteaForge) GetFile(ctx context.Context, path string) (*File, error) {
+	resp, err := g.doReq(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/contents/%s", g.repo, path), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpError("get file", resp)
+	}
+	var info struct {
+		SHA     string `json:"sha"`
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(info.Content, "\n", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &File{Content: string(raw), SHA: info.SHA}, nil
+}
+
+func (g *giteaForge) PutFile(ctx context.Context, path, branch, sha, content, commitMessage string) error {
+	resp, err := g.doReq(ctx, http.MethodPut, fmt.Sprintf("/repos/%s/contents/%s", g.repo, path), map[string]string{
+		"message": commitMessage,
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+		"sha":     sha,
+		"branch":  branch,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return httpError("put file", resp)
+	}
+	return nil
+}
+
+func (g *giteaForge) CreateBranch(ctx context.Context, branch, base string) error {
+	resp, err := g.doReq(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/branches", g.repo), map[string]string{
+		"new_branch_name": branch,
+		"old_branch_name": base,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return httpError("create branch", resp)
+	}
+	return nil
+}
+
+func (g *giteaForge) ListBranches(ctx context.Context, prefix string) ([]Branch, error) {
+	resp, err := g.doReq(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/branches?limit=100", g.repo), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpError("list branches", resp)
+	}
+	var raw []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	var branches []Branch
+	for _, b := range raw {
+		if strings.HasPrefix(b.Name, prefix) {
+			branches = append(branches, Branch{Name: b.Name, SHA: b.Commit.ID})
+		}
+	}
+	return branches, nil
+}
+
+func (g *giteaForge) DeleteBranch(ctx context.Context, name string) error {
+	resp, err := g.doReq(ctx, http.MethodDelete, fmt.Sprintf("/repos/%s/branches/%s", g.repo, name), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return httpError("delete branch", resp)
+	}
+	return nil
+}
+
+func (g *giteaForge) PullRequestStateForBranch(ctx context.Context, head string) (string, error) {
+	q := url.Values{"state": {"all"}, "limit": {"50"}}
+	resp, err := g.doReq(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls?%s", g.repo, q.Encode()), nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", httpError("list pull requests", resp)
+	}
+	var prs []struct {
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		State  string `json:"state"`
+		Merged bool   `json:"merged"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&prs); err != nil {
+		return "", err
+	}
+	for _, pr := range prs {
+		if pr.Head.Ref != head {
+			continue
+		}
+		if pr.Merged {
+			return "merged", nil
+		}
+		return pr.State, nil
+	}
+	return "none", nil
+}
+
+func (g *giteaForge) OpenPullRequest(ctx context.Context, title, body, head, base string) (*PullRequest, error) {
+	resp, err := g.doReq(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/pulls", g.repo), map[string]string{
+		"title": title,
+		"body":  body,
+		"head":  head,
+		"base":  base,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, httpError("open pull request", resp)
+	}
+	var pr struct {
+		Number int    `json:"number"`
+		URL    string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pr); err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: pr.Number, URL: pr.URL}, nil
+}
+
+func (g *giteaForge) AddLabels(ctx context.Context, number int, labels []string) error {
+	resp, err := g.doReq(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/issues/%d/labels", g.repo, number), map[string]any{
+		"labels": labels,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return httpError("add labels", resp)
+	}
+	return nil
+}
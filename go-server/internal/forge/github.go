@@ -0,0 +1,320 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// githubForge implements Forge against the GitHub REST API.
+type githubForge struct {
+	baseURL string
+	repo    string
+	token   string
+	client  *http.Client
+}
+
+func newGitHubForge(cfg Config) *githubForge {
+	base := cfg.BaseURL
+	if base == "" {
+		base = "https://api.github.com"
+	}
+	return &githubForge{baseURL: base, repo: cfg.Repo, token: cfg.Token, client: http.DefaultClient}
+}
+
+func (g *githubForge) doReq(ctx context.Context, method, url string, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return g.client.Do(req)
+}
+
+func (g *githubForge) OpenIssue(ctx context.Context, title, body string, labels []string) (*Issue, error) {
+	url := fmt.Sprintf("%s/repos/%s/issues", g.baseURL, g.repo)
+	resp, err := g.doReq(ctx, http.MethodPost, url, map[string]any{
+		"title":  title,
+		"body":   body,
+		"labels": labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, httpError("create issue", resp)
+	}
+	var created struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, err
+	}
+	return &Issue{Number: created.Number, URL: created.HTMLURL}, nil
+}
+
+func (g *githubForge) ListIssues(ctx context.Context, label string) ([]IssueDetail, error) {
+	url := fmt.Sprintf("%s/repos/%s/issues?state=all&labels=%s&per_page=100", g.baseURL, g.repo, label)
+	resp, err := g.doReq(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpError("list issues", resp)
+	}
+	var raw []struct {
+		Number    int       `json:"number"`
+		Title     string    `json:"title"`
+		Body      string    `json:"body"`
+		State     string    `json:"state"`
+		CreatedAt time.Time `json:"created_at"`
+		HTMLURL   string    `json:"html_url"`
+		PullReq   *struct{} `json:"pull_request"` // GitHub lists PRs as issues too; skip them
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	issues := make([]IssueDetail, 0, len(raw))
+	for _, r := range raw {
+		if r.PullReq != nil {
+			continue
+		}
+		issues = append(issues, IssueDetail{Number: r.Number, Title: r.Title, Body: r.Body, State: r.State, CreatedAt: r.CreatedAt, URL: r.HTMLURL})
+	}
+	return issues, nil
+}
+
+func (g *githubForge) UpdateIssueBody(ctx context.Context, number int, body string) error {
+	url := fmt.Sprintf("%s/repos/%s/issues/%d", g.baseURL, g.repo, number)
+	resp, err := g.doReq(ctx, http.MethodPatch, url, map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return httpError("update issue", resp)
+	}
+	return nil
+}
+
+func (g *githubForge) CloseIssue(ctx context.Context, number int) error {
+	url := fmt.Sprintf("%s/repos/%s/issues/%d", g.baseURL, g.repo, number)
+	resp, err := g.doReq(ctx, http.MethodPatch, url, map[string]string{"state": "closed"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return httpError("close issue", resp)
+	}
+	return nil
+}
+
+func (g *githubForge) GetFile(ctx context.Context, path string) (*File, error) {
+	url := fmt.Sprintf("%s/repos/%s/contents/%s", g.baseURL, g.repo, path)
+	resp, err := g.doReq(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpError("get file", resp)
+	}
+	var info struct {
+		SHA     string `json:"sha"`
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(info.Content, "\n", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &File{Content: string(raw), SHA: info.SHA}, nil
+}
+
+func (g *githubForge) PutFile(ctx context.Context, path, branch, sha, content, commitMessage string) error {
+	url := fmt.Sprintf("%s/repos/%s/contents/%s", g.baseURL, g.repo, path)
+	resp, err := g.doReq(ctx, http.MethodPut, url, map[string]string{
+		"message": commitMessage,
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+		"sha":     sha,
+		"branch":  branch,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return httpError("put file", resp)
+	}
+	return nil
+}
+
+func (g *githubForge) CreateBranch(ctx context.Context, branch, base string) error {
+	refURL := fmt.Sprintf("%s/repos/%s/git/ref/heads/%s", g.baseURL, g.repo, base)
+	resp, err := g.doReq(ctx, http.MethodGet, refURL, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return httpError("get base ref", resp)
+	}
+	var refInfo struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&refInfo); err != nil {
+		return err
+	}
+
+	createURL := fmt.Sprintf("%s/repos/%s/git/refs", g.baseURL, g.repo)
+	resp, err = g.doReq(ctx, http.MethodPost, createURL, map[string]string{
+		"ref": "refs/heads/" + branch,
+		"sha": refInfo.Object.SHA,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return httpError("create branch", resp)
+	}
+	return nil
+}
+
+func (g *githubForge) ListBranches(ctx context.Context, prefix string) ([]Branch, error) {
+	url := fmt.Sprintf("%s/repos/%s/branches?per_page=100", g.baseURL, g.repo)
+	resp, err := g.doReq(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpError("list branches", resp)
+	}
+	var raw []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	var branches []Branch
+	for _, b := range raw {
+		if strings.HasPrefix(b.Name, prefix) {
+			branches = append(branches, Branch{Name: b.Name, SHA: b.Commit.SHA})
+		}
+	}
+	return branches, nil
+}
+
+func (g *githubForge) DeleteBranch(ctx context.Context, name string) error {
+	url := fmt.Sprintf("%s/repos/%s/git/refs/heads/%s", g.baseURL, g.repo, name)
+	resp, err := g.doReq(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return httpError("delete branch", resp)
+	}
+	return nil
+}
+
+func (g *githubForge) PullRequestStateForBranch(ctx context.Context, head string) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls?state=all&head=%s:%s&per_page=1", g.baseURL, g.repo, strings.SplitN(g.repo, "/", 2)[0], head)
+	resp, err := g.doReq(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", httpError("list pull requests", resp)
+	}
+	var prs []struct {
+		State   string     `json:"state"`
+		Merged  bool       `json:"merged"`
+		MergeAt *time.Time `json:"merged_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&prs); err != nil {
+		return "", err
+	}
+	if len(prs) == 0 {
+		return "none", nil
+	}
+	pr := prs[0]
+	if pr.MergeAt != nil || pr.Merged {
+		return "merged", nil
+	}
+	return pr.State, nil // "open" or "closed"
+}
+
+func (g *githubForge) OpenPullRequest(ctx context.Context, title, body, head, base string) (*PullRequest, error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls", g.baseURL, g.repo)
+	resp, err := g.doReq(ctx, http.MethodPost, url, map[string]string{
+		"title": title,
+		"body":  body,
+		"head":  head,
+		"base":  base,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, httpError("open pull request", resp)
+	}
+	var pr struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pr); err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: pr.Number, URL: pr.HTMLURL}, nil
+}
+
+func (g *githubForge) AddLabels(ctx context.Context, number int, labels []string) error {
+	url := fmt.Sprintf("%s/repos/%s/issues/%d/labels", g.baseURL, g.repo, number)
+	resp, err := g.doReq(ctx, http.MethodPost, url, labels)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return httpError("add labels", resp)
+	}
+	return nil
+}
+
+func httpError(action string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s: HTTP %d: %s", action, resp.StatusCode, string(body))
+}
@@ -0,0 +1,99 @@
+package models
+
+// Model describes a tracked model ID and the metadata the cheatsheet exposes
+// to MCP clients and the updater's deprecation workflow.
+type Model struct {
+	Provider      string
+	Family        string
+	Modality      string
+	ContextWindow int
+	Status        string // "current", "legacy", or "deprecated"
+	DeprecatedOn  string `json:",omitempty"`
+	Replacement   string `json:",omitempty"`
+}
+
+// Models is the canonical registry of every model ID the cheatsheet tracks,
+// keyed by model ID. Generated from the registry by cmd/genmodels — see
+// that package's doc comment before editing this file by hand.
+var Models = map[string]Model{
+	"jamba-large-1.7":             {Provider: "AI21", Family: "jamba-large-1.7", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"jamba-mini-1.7":              {Provider: "AI21", Family: "jamba-mini-1.7", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"amazon-nova-micro":           {Provider: "Amazon", Family: "amazon-nova-micro", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"amazon-nova-lite":            {Provider: "Amazon", Family: "amazon-nova-lite", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"amazon-nova-pro":             {Provider: "Amazon", Family: "amazon-nova-pro", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"amazon-nova-premier":         {Provider: "Amazon", Family: "amazon-nova-premier", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"amazon-nova-2-lite":          {Provider: "Amazon", Family: "amazon-nova-2-lite", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"amazon-nova-2-pro":           {Provider: "Amazon", Family: "amazon-nova-2-pro", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"claude-opus-4-6":             {Provider: "Anthropic", Family: "claude-opus-4-6", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"claude-sonnet-4-5-20250929":  {Provider: "Anthropic", Family: "claude-sonnet-4-5", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"claude-haiku-4-5-20251001":   {Provider: "Anthropic", Family: "claude-haiku-4-5", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"claude-opus-4-5":             {Provider: "Anthropic", Family: "claude-opus-4-5", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"claude-opus-4-1":             {Provider: "Anthropic", Family: "claude-opus-4-1", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"claude-sonnet-4-0":           {Provider: "Anthropic", Family: "claude-sonnet-4-0", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"claude-3-7-sonnet-20250219":  {Provider: "Anthropic", Family: "claude-3-7-sonnet", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"claude-opus-4-0":             {Provider: "Anthropic", Family: "claude-opus-4-0", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"command-a-03-2025":           {Provider: "Cohere", Family: "command-a-03", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"command-a-reasoning-08-2025": {Provider: "Cohere", Family: "command-a-reasoning-08", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"command-a-vision-07-2025":    {Provider: "Cohere", Family: "command-a-vision-07", Modality: "multimodal", ContextWindow: 128000, Status: "current"},
+	"command-r7b-12-2024":         {Provider: "Cohere", Family: "command-r7b-12", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"deepseek-reasoner":           {Provider: "DeepSeek", Family: "deepseek-reasoner", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"deepseek-chat":               {Provider: "DeepSeek", Family: "deepseek-chat", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"deepseek-r1":                 {Provider: "DeepSeek", Family: "deepseek-r1", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"deepseek-v3":                 {Provider: "DeepSeek", Family: "deepseek-v3", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gemini-3-pro-preview":        {Provider: "Google", Family: "gemini-3-pro-preview", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"gemini-3-flash-preview":      {Provider: "Google", Family: "gemini-3-flash-preview", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gemini-2.5-pro":              {Provider: "Google", Family: "gemini-2.5-pro", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"gemini-2.5-flash":            {Provider: "Google", Family: "gemini-2.5-flash", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gemini-2.5-flash-lite":       {Provider: "Google", Family: "gemini-2.5-flash-lite", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gemini-2.0-flash":            {Provider: "Google", Family: "gemini-2.0-flash", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"kwaiyii-13b":                 {Provider: "Kuaishou", Family: "kwaiyii-13b", Modality: "text", ContextWindow: 32000, Status: "current"},
+	"kwaiyii-6b":                  {Provider: "Kuaishou", Family: "kwaiyii-6b", Modality: "text", ContextWindow: 32000, Status: "current"},
+	"llama-4-maverick":            {Provider: "Meta", Family: "llama-4-maverick", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"llama-4-scout":               {Provider: "Meta", Family: "llama-4-scout", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"llama-3.3-70b":               {Provider: "Meta", Family: "llama-3.3-70b", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"phi-4":                       {Provider: "Microsoft", Family: "phi-4", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"phi-4-mini":                  {Provider: "Microsoft", Family: "phi-4-mini", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"minimax-m2":                  {Provider: "MiniMax", Family: "minimax-m2", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"minimax-text-01":             {Provider: "MiniMax", Family: "minimax-text-01", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"mistral-large-2512":          {Provider: "Mistral", Family: "mistral-large", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"mistral-medium-2505":         {Provider: "Mistral", Family: "mistral-medium", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"mistral-small-2506":          {Provider: "Mistral", Family: "mistral-small", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"devstral-2512":               {Provider: "Mistral", Family: "devstral", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"devstral-small-2512":         {Provider: "Mistral", Family: "devstral-small", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"codestral-2508":              {Provider: "Mistral", Family: "codestral", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"kimi-k2":                     {Provider: "Moonshot", Family: "kimi-k2", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"moonshot-v1-128k":            {Provider: "Moonshot", Family: "moonshot-v1-128k", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"nemotron-4-340b-instruct":    {Provider: "NVIDIA", Family: "nemotron-4-340b-instruct", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"nemotron-nano-9b":            {Provider: "NVIDIA", Family: "nemotron-nano-9b", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-5.2":                     {Provider: "OpenAI", Family: "gpt-5.2", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-5.2-codex":               {Provider: "OpenAI", Family: "gpt-5.2-codex", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-5.2-pro":                 {Provider: "OpenAI", Family: "gpt-5.2-pro", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"gpt-5.1":                     {Provider: "OpenAI", Family: "gpt-5.1", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-5":                       {Provider: "OpenAI", Family: "gpt-5", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-5-mini":                  {Provider: "OpenAI", Family: "gpt-5-mini", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-5-nano":                  {Provider: "OpenAI", Family: "gpt-5-nano", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-4.1-mini":                {Provider: "OpenAI", Family: "gpt-4.1-mini", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-4.1-nano":                {Provider: "OpenAI", Family: "gpt-4.1-nano", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"o3":                          {Provider: "OpenAI", Family: "o3", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"o3-pro":                      {Provider: "OpenAI", Family: "o3-pro", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"o4-mini":                     {Provider: "OpenAI", Family: "o4-mini", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"o3-mini":                     {Provider: "OpenAI", Family: "o3-mini", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-4.1":                     {Provider: "OpenAI", Family: "gpt-4.1", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-4o":                      {Provider: "OpenAI", Family: "gpt-4o", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"gpt-4o-mini":                 {Provider: "OpenAI", Family: "gpt-4o-mini", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"sonar":                       {Provider: "Perplexity", Family: "sonar", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"sonar-pro":                   {Provider: "Perplexity", Family: "sonar-pro", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"sonar-reasoning-pro":         {Provider: "Perplexity", Family: "sonar-reasoning-pro", Modality: "text", ContextWindow: 200000, Status: "current"},
+	"hunyuan-turbo-s":             {Provider: "Tencent", Family: "hunyuan-turbo-s", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"hunyuan-lite":                {Provider: "Tencent", Family: "hunyuan-lite", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"mimo-v1":                     {Provider: "Xiaomi", Family: "mimo-v1", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"mimo-7b":                     {Provider: "Xiaomi", Family: "mimo-7b", Modality: "text", ContextWindow: 32000, Status: "current"},
+	"glm-4.6":                     {Provider: "Zhipu", Family: "glm-4.6", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"glm-4.5-air":                 {Provider: "Zhipu", Family: "glm-4.5-air", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"grok-4":                      {Provider: "xAI", Family: "grok-4", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"grok-4.1-fast":               {Provider: "xAI", Family: "grok-4.1-fast", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"grok-4-fast":                 {Provider: "xAI", Family: "grok-4-fast", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"grok-code-fast-1":            {Provider: "xAI", Family: "grok-code-fast-1", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"grok-3":                      {Provider: "xAI", Family: "grok-3", Modality: "text", ContextWindow: 128000, Status: "current"},
+	"grok-3-mini":                 {Provider: "xAI", Family: "grok-3-mini", Modality: "text", ContextWindow: 128000, Status: "current"},
+}
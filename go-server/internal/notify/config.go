@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+
+	"go-server/internal/registry"
+)
+
+// SinksFromConfigs builds a Sink per entry in cfgs. An entry with an empty
+// destination (webhook_url/url/smtp_host) is treated as not configured and
+// silently skipped, so the seed registry.yaml can ship all four kinds as
+// placeholders with empty destinations. An entry with an unrecognized
+// Kind is an error.
+func SinksFromConfigs(cfgs []registry.NotifierConfig) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(cfgs))
+	for i, c := range cfgs {
+		n, err := notifierFromConfig(c)
+		if err != nil {
+			return nil, fmt.Errorf("notifiers[%d]: %w", i, err)
+		}
+		if n == nil {
+			continue
+		}
+
+		var providers map[string]bool
+		if len(c.Providers) > 0 {
+			providers = make(map[string]bool, len(c.Providers))
+			for _, p := range c.Providers {
+				providers[p] = true
+			}
+		}
+
+		sinks = append(sinks, Sink{
+			Notifier:  n,
+			OnNew:     c.OnNew,
+			OnMissing: c.OnMissing,
+			OnError:   c.OnError,
+			Providers: providers,
+			Timeout:   time.Duration(c.TimeoutSeconds) * time.Second,
+			Retries:   c.Retries,
+		})
+	}
+	return sinks, nil
+}
+
+// notifierFromConfig returns (nil, nil) for a recognized but unconfigured
+// sink (empty destination) so it's skipped rather than treated as an error.
+func notifierFromConfig(c registry.NotifierConfig) (Notifier, error) {
+	switch c.Kind {
+	case "slack":
+		if c.WebhookURL == "" {
+			return nil, nil
+		}
+		return NewSlack(c.WebhookURL), nil
+	case "discord":
+		if c.WebhookURL == "" {
+			return nil, nil
+		}
+		return NewDiscord(c.WebhookURL), nil
+	case "webhook":
+		if c.URL == "" {
+			return nil, nil
+		}
+		return NewWebhook(c.URL), nil
+	case "email":
+		if c.SMTPHost == "" {
+			return nil, nil
+		}
+		if len(c.To) == 0 {
+			return nil, fmt.Errorf("email notifier: smtp_host is set but to has no recipients")
+		}
+		return NewEmail(c.SMTPHost, c.SMTPPort, c.SMTPUser, c.SMTPPassEnv, c.From, c.To), nil
+	default:
+		return nil, fmt.Errorf("unsupported notifier kind %q", c.Kind)
+	}
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"testing"
+
+	"go-server/internal/registry"
+)
+
+func TestSinksFromConfigs_SkipsUnconfiguredSinks(t *testing.T) {
+	sinks, err := SinksFromConfigs([]registry.NotifierConfig{
+		{Kind: "slack", WebhookURL: ""},
+		{Kind: "webhook", URL: "https://example.com/hook"},
+	})
+	if err != nil {
+		t.Fatalf("SinksFromConfigs: %v", err)
+	}
+	if len(sinks) != 1 {
+		t.Fatalf("expected 1 configured sink, got %d", len(sinks))
+	}
+	if sinks[0].Notifier.Name() != "webhook" {
+		t.Errorf("expected the webhook sink to survive, got %q", sinks[0].Notifier.Name())
+	}
+}
+
+func TestSinksFromConfigs_RejectsUnknownKind(t *testing.T) {
+	if _, err := SinksFromConfigs([]registry.NotifierConfig{{Kind: "pagerduty"}}); err == nil {
+		t.Error("expected an error for an unsupported notifier kind")
+	}
+}
+
+func TestSinksFromConfigs_RejectsEmailWithoutRecipients(t *testing.T) {
+	if _, err := SinksFromConfigs([]registry.NotifierConfig{{Kind: "email", SMTPHost: "smtp.example.com"}}); err == nil {
+		t.Error("expected an error for an email sink with no recipients")
+	}
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// discordMessageLimit is Discord's hard cap on a webhook message's content
+// field; longer reports are truncated rather than rejected outright.
+const discordMessageLimit = 2000
+
+// discordNotifier posts to a Discord webhook.
+type discordNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewDiscord builds a Notifier that posts to a Discord webhook URL.
+func NewDiscord(webhookURL string) Notifier {
+	return &discordNotifier{webhookURL: webhookURL, client: http.DefaultClient}
+}
+
+func (d *discordNotifier) Name() string { return "discord" }
+
+func (d *discordNotifier) Notify(ctx context.Context, r Report) error {
+	content := renderText(r)
+	if len(content) > discordMessageLimit {
+		content = content[:discordMessageLimit-3] + "..."
+	}
+
+	payload, err := json.Marshal(map[string]string{"content": content})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("discord webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+// emailNotifier sends a report over SMTP. Auth is PLAIN and the password is
+// read from an environment variable at send time, never stored in config.
+type emailNotifier struct {
+	host     string
+	port     int
+	user     string
+	passEnv  string
+	from     string
+	to       []string
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmail builds a Notifier that sends a report via SMTP. passEnv names
+// the environment variable holding the SMTP password.
+func NewEmail(host string, port int, user, passEnv, from string, to []string) Notifier {
+	return &emailNotifier{host: host, port: port, user: user, passEnv: passEnv, from: from, to: to, sendMail: smtp.SendMail}
+}
+
+func (e *emailNotifier) Name() string { return "email" }
+
+func (e *emailNotifier) Notify(ctx context.Context, r Report) error {
+	if len(e.to) == 0 {
+		return fmt.Errorf("email notifier: no recipients configured")
+	}
+
+	subject := fmt.Sprintf("Model registry update: %s", r.Provider)
+	body := renderText(r)
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", e.from, strings.Join(e.to, ", "), subject, body)
+
+	addr := fmt.Sprintf("%s:%d", e.host, e.port)
+	var auth smtp.Auth
+	if e.user != "" {
+		auth = smtp.PlainAuth("", e.user, os.Getenv(e.passEnv), e.host)
+	}
+	return e.sendMail(addr, auth, e.from, e.to, []byte(msg))
+}
@@ -0,0 +1,162 @@
+// Package notify fans a model-registry change report out to Slack,
+// Discord, generic webhook, and email sinks, mirroring the multi-sink
+// notifier pattern used by services like Forgejo: one small interface,
+// several interchangeable implementations, configured declaratively and
+// invoked independently of each other.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Report describes what changed for a single provider during one update
+// check. A zero-value Errors/NewIDs/MissingIDs slice means "none of that
+// kind" — sinks decide whether an empty report is worth sending via their
+// filters.
+type Report struct {
+	Provider   string
+	NewIDs     []string
+	MissingIDs []string
+	Errors     []string
+}
+
+// Notifier delivers a Report to one destination.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, r Report) error
+}
+
+const (
+	defaultTimeout = 10 * time.Second
+	defaultRetries = 1
+)
+
+// Sink pairs a Notifier with the filters that decide which reports it
+// should receive and the retry/timeout policy for delivering them.
+type Sink struct {
+	Notifier  Notifier
+	OnNew     bool
+	OnMissing bool
+	OnError   bool
+	Providers map[string]bool // nil or empty means all providers
+	Timeout   time.Duration
+	Retries   int
+}
+
+func (s Sink) matches(r Report) bool {
+	if len(s.Providers) > 0 && !s.Providers[r.Provider] {
+		return false
+	}
+	switch {
+	case len(r.Errors) > 0 && s.OnError:
+		return true
+	case len(r.NewIDs) > 0 && s.OnNew:
+		return true
+	case len(r.MissingIDs) > 0 && s.OnMissing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Dispatcher fans a Report out to every Sink whose filters match,
+// in parallel, so a slow or failing sink can't delay the others.
+type Dispatcher struct {
+	sinks []Sink
+}
+
+// NewDispatcher builds a Dispatcher from a fixed set of sinks.
+func NewDispatcher(sinks []Sink) *Dispatcher {
+	return &Dispatcher{sinks: sinks}
+}
+
+// dispatchResult reports the outcome of delivering a Report to one sink.
+type dispatchResult struct {
+	sink string
+	err  error
+}
+
+// Dispatch delivers r to every matching sink concurrently and returns one
+// error per sink that failed after retries, in no particular order. A nil
+// slice means every matching sink succeeded (or none matched).
+func (d *Dispatcher) Dispatch(ctx context.Context, r Report) []error {
+	var matching []Sink
+	for _, s := range d.sinks {
+		if s.matches(r) {
+			matching = append(matching, s)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+
+	results := make(chan dispatchResult, len(matching))
+	for _, s := range matching {
+		go func(s Sink) {
+			results <- dispatchResult{sink: s.Notifier.Name(), err: deliverWithRetry(ctx, s, r)}
+		}(s)
+	}
+
+	var errs []error
+	for range matching {
+		if res := <-results; res.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.sink, res.err))
+		}
+	}
+	return errs
+}
+
+func deliverWithRetry(ctx context.Context, s Sink, r Report) error {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	retries := s.Retries
+	if retries <= 0 {
+		retries = defaultRetries
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= retries; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		lastErr = s.Notifier.Notify(attemptCtx, r)
+		cancel()
+		if lastErr == nil {
+			return nil
+		}
+		if attempt < retries {
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+	}
+	return lastErr
+}
+
+// renderText formats a Report as the plain-text message body shared by the
+// chat-style sinks (Slack, Discord); each sink wraps this in its own
+// envelope (a "text" field, a "content" field, ...).
+func renderText(r Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Model registry update: %s\n", r.Provider)
+	if len(r.Errors) > 0 {
+		fmt.Fprintf(&b, "Errors (%d):\n", len(r.Errors))
+		for _, e := range r.Errors {
+			fmt.Fprintf(&b, "  ! %s\n", e)
+		}
+	}
+	if len(r.NewIDs) > 0 {
+		fmt.Fprintf(&b, "New (%d):\n", len(r.NewIDs))
+		for _, id := range r.NewIDs {
+			fmt.Fprintf(&b, "  + %s\n", id)
+		}
+	}
+	if len(r.MissingIDs) > 0 {
+		fmt.Fprintf(&b, "Missing (%d):\n", len(r.MissingIDs))
+		for _, id := range r.MissingIDs {
+			fmt.Fprintf(&b, "  - %s\n", id)
+		}
+	}
+	return b.String()
+}
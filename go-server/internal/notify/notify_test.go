@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type fakeNotifier struct {
+	name string
+	mu   sync.Mutex
+	got  []Report
+	err  error
+}
+
+func (f *fakeNotifier) Name() string { return f.name }
+
+func (f *fakeNotifier) Notify(ctx context.Context, r Report) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.got = append(f.got, r)
+	return f.err
+}
+
+func (f *fakeNotifier) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.got)
+}
+
+func TestSink_MatchesRespectsFilters(t *testing.T) {
+	s := Sink{OnNew: true}
+	if s.matches(Report{Provider: "OpenAI", MissingIDs: []string{"x"}}) {
+		t.Error("expected a missing-only report to not match an on_new-only sink")
+	}
+	if !s.matches(Report{Provider: "OpenAI", NewIDs: []string{"x"}}) {
+		t.Error("expected a new-model report to match an on_new sink")
+	}
+}
+
+func TestSink_MatchesRespectsProviderAllowlist(t *testing.T) {
+	s := Sink{OnNew: true, Providers: map[string]bool{"OpenAI": true}}
+	if s.matches(Report{Provider: "Anthropic", NewIDs: []string{"x"}}) {
+		t.Error("expected a non-allowlisted provider to not match")
+	}
+	if !s.matches(Report{Provider: "OpenAI", NewIDs: []string{"x"}}) {
+		t.Error("expected the allowlisted provider to match")
+	}
+}
+
+func TestDispatcher_OnlyInvokesMatchingSinks(t *testing.T) {
+	onNew := &fakeNotifier{name: "new-only"}
+	onMissing := &fakeNotifier{name: "missing-only"}
+	d := NewDispatcher([]Sink{
+		{Notifier: onNew, OnNew: true},
+		{Notifier: onMissing, OnMissing: true},
+	})
+
+	errs := d.Dispatch(context.Background(), Report{Provider: "OpenAI", NewIDs: []string{"gpt-5"}})
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if onNew.calls() != 1 {
+		t.Errorf("expected the on_new sink to fire once, got %d", onNew.calls())
+	}
+	if onMissing.calls() != 0 {
+		t.Errorf("expected the on_missing sink to not fire, got %d", onMissing.calls())
+	}
+}
+
+func TestDispatcher_ReturnsErrorsFromFailingSinks(t *testing.T) {
+	failing := &fakeNotifier{name: "flaky", err: errors.New("boom")}
+	d := NewDispatcher([]Sink{{Notifier: failing, OnNew: true, Retries: 1}})
+
+	errs := d.Dispatch(context.Background(), Report{Provider: "OpenAI", NewIDs: []string{"gpt-5"}})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestDispatcher_NoMatchingSinksIsNotAnError(t *testing.T) {
+	d := NewDispatcher([]Sink{{Notifier: &fakeNotifier{name: "new-only"}, OnNew: true}})
+	if errs := d.Dispatch(context.Background(), Report{Provider: "OpenAI"}); errs != nil {
+		t.Errorf("expected no errors for an empty report, got %v", errs)
+	}
+}
@@ -0,0 +1,45 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("AI21", NewAI21)
+}
+
+// ai21Provider treats only a trailing "-beta"/"-preview" suffix as an
+// alias of the base model. AI21 doesn't date-stamp or numerically
+// revision its Jamba model IDs, so no other heuristic applies here.
+type ai21Provider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewAI21 builds the AI21 Provider.
+func NewAI21(name string, cfg registry.Provider) Provider {
+	return &ai21Provider{name: name, cfg: cfg}
+}
+
+func (p *ai21Provider) Name() string { return p.name }
+
+func (p *ai21Provider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *ai21Provider) Canonicalize(id string) string { return id }
+
+func (p *ai21Provider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	for _, suffix := range []string{"beta", "preview"} {
+		if base, ok := strings.CutSuffix(id, "-"+suffix); ok && known[base] {
+			return Alias
+		}
+	}
+	return New
+}
@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"context"
+	"regexp"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("Amazon", NewAmazon)
+}
+
+// bedrockVersionSuffix matches Bedrock's trailing "-v1:0"-style version
+// tag, e.g. "amazon.titan-text-express-v1:0" or "amazon.nova-pro-v2:0".
+var bedrockVersionSuffix = regexp.MustCompile(`-v\d+:\d+$`)
+
+// amazonProvider classifies a trailing Bedrock version tag as a variant
+// of the unversioned model name rather than a new model.
+type amazonProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewAmazon builds the Amazon Provider.
+func NewAmazon(name string, cfg registry.Provider) Provider {
+	return &amazonProvider{name: name, cfg: cfg}
+}
+
+func (p *amazonProvider) Name() string { return p.name }
+
+func (p *amazonProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *amazonProvider) Canonicalize(id string) string { return id }
+
+func (p *amazonProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	if loc := bedrockVersionSuffix.FindStringIndex(id); loc != nil {
+		base := id[:loc[0]]
+		if known[base] {
+			return NumericRevision
+		}
+	}
+	return New
+}
@@ -0,0 +1,48 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("Anthropic", NewAnthropic)
+}
+
+// anthropicProvider classifies Anthropic's 8-digit snapshot dates
+// (e.g. "claude-sonnet-4-5-20250929") as variants of the bare model name.
+// Anthropic doesn't use "-latest"/"-preview" style aliases the way OpenAI
+// does, so that's the only rule this provider needs.
+type anthropicProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewAnthropic builds the Anthropic Provider.
+func NewAnthropic(name string, cfg registry.Provider) Provider {
+	return &anthropicProvider{name: name, cfg: cfg}
+}
+
+func (p *anthropicProvider) Name() string { return p.name }
+
+func (p *anthropicProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *anthropicProvider) Canonicalize(id string) string { return id }
+
+func (p *anthropicProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	suffix := lastToken(id)
+	if isAllDigits(suffix) && len(suffix) == 8 {
+		base := strings.TrimSuffix(id, "-"+suffix)
+		if known[base] {
+			return DateVariant
+		}
+	}
+	return New
+}
@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("Cohere", NewCohere)
+}
+
+// cohereProvider classifies a trailing "MM-YYYY" date suffix (e.g.
+// "command-r-plus-08-2024") as a variant of the undated model; unlike
+// Anthropic's "YYYYMMDD" stamp, Cohere splits the date across two
+// trailing tokens in month-then-year order, so it needs its own check.
+type cohereProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewCohere builds the Cohere Provider.
+func NewCohere(name string, cfg registry.Provider) Provider {
+	return &cohereProvider{name: name, cfg: cfg}
+}
+
+func (p *cohereProvider) Name() string { return p.name }
+
+func (p *cohereProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *cohereProvider) Canonicalize(id string) string { return id }
+
+func (p *cohereProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	tokens := strings.Split(id, "-")
+	if len(tokens) >= 2 && isAllDigits(tokens[len(tokens)-1]) && len(tokens[len(tokens)-1]) == 4 &&
+		isAllDigits(tokens[len(tokens)-2]) && len(tokens[len(tokens)-2]) == 2 {
+		base := strings.Join(tokens[:len(tokens)-2], "-")
+		if known[base] {
+			return DateVariant
+		}
+	}
+	return New
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("DeepSeek", NewDeepSeek)
+}
+
+// deepSeekProvider classifies a trailing 4-digit MMDD refresh tag (e.g.
+// DeepSeek's "-0324"-style in-place model updates) as a revision of the
+// un-suffixed model, the same shape as Mistral's YYMM tags but kept as a
+// separate rule since the two providers don't share a release cadence.
+type deepSeekProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewDeepSeek builds the DeepSeek Provider.
+func NewDeepSeek(name string, cfg registry.Provider) Provider {
+	return &deepSeekProvider{name: name, cfg: cfg}
+}
+
+func (p *deepSeekProvider) Name() string { return p.name }
+
+func (p *deepSeekProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *deepSeekProvider) Canonicalize(id string) string { return id }
+
+func (p *deepSeekProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	suffix := lastToken(id)
+	if isAllDigits(suffix) && len(suffix) == 4 {
+		if base := strings.TrimSuffix(id, "-"+suffix); known[base] {
+			return NumericRevision
+		}
+	}
+	return New
+}
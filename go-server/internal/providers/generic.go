@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+// modifierWords are trailing release-channel words the generic fallback
+// treats as an alias suffix rather than part of a model's family name.
+var modifierWords = map[string]bool{
+	"latest": true, "beta": true, "preview": true, "chat": true,
+	"reasoning": true, "non": true,
+}
+
+// genericProvider is the fallback Build uses for any provider name without
+// a bespoke implementation (openai.go, anthropic.go, ...): it groups IDs by
+// a family name derived by peeling off trailing modifier words and a
+// trailing date/numeric-revision suffix, the same shape of heuristic
+// cmd/updater used globally before each provider got its own rules.
+type genericProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewGeneric builds the family-based fallback Provider for name/cfg.
+func NewGeneric(name string, cfg registry.Provider) Provider {
+	return &genericProvider{name: name, cfg: cfg}
+}
+
+func (p *genericProvider) Name() string { return p.name }
+
+func (p *genericProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *genericProvider) Canonicalize(id string) string { return id }
+
+func (p *genericProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	family, _, isDate := familyOf(id)
+	for k := range known {
+		kFamily, _, _ := familyOf(k)
+		if kFamily != family {
+			continue
+		}
+		if isDate {
+			return DateVariant
+		}
+		tokens := strings.Split(id, "-")
+		if len(tokens) > 0 && modifierWords[tokens[len(tokens)-1]] {
+			return Alias
+		}
+		return NumericRevision
+	}
+	return New
+}
+
+// familyOf splits id into a family name and a trailing version suffix
+// (bare digit run of 4+, or a YYYY-MM-DD triple), peeling modifier words
+// off the end first. isDate reports whether the suffix looks like a full
+// calendar date rather than a short revision tag.
+func familyOf(id string) (family, version string, isDate bool) {
+	tokens := strings.Split(id, "-")
+	end := len(tokens)
+	for end > 1 && modifierWords[tokens[end-1]] {
+		end--
+	}
+	if end >= 3 && isDateTriple(tokens[end-3], tokens[end-2], tokens[end-1]) {
+		return strings.Join(tokens[:end-3], "-"), strings.Join(tokens[end-3:end], "-"), true
+	}
+	if end >= 2 && isAllDigits(tokens[end-1]) && len(tokens[end-1]) >= 4 {
+		return strings.Join(tokens[:end-1], "-"), tokens[end-1], len(tokens[end-1]) == 8
+	}
+	return strings.Join(tokens[:end], "-"), "", false
+}
+
+func isDateTriple(y, m, d string) bool {
+	return len(y) == 4 && isAllDigits(y) &&
+		len(m) == 2 && isAllDigits(m) &&
+		len(d) == 2 && isAllDigits(d)
+}
@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("Google", NewGoogle)
+}
+
+// googleProvider strips the "models/" resource prefix the Gemini API
+// returns (Canonicalize) and treats a trailing 3-digit revision
+// ("-001", "-002", ...) as a variant of the unversioned model rather than
+// a new one.
+type googleProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewGoogle builds the Google Provider.
+func NewGoogle(name string, cfg registry.Provider) Provider {
+	return &googleProvider{name: name, cfg: cfg}
+}
+
+func (p *googleProvider) Name() string { return p.name }
+
+func (p *googleProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	ids, err := fetchGeneric(ctx, p.name, p.cfg)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		ids[i] = p.Canonicalize(id)
+	}
+	return ids, nil
+}
+
+// Canonicalize strips the Gemini API's "models/" resource prefix, e.g.
+// "models/gemini-2.5-pro" becomes "gemini-2.5-pro".
+func (p *googleProvider) Canonicalize(id string) string {
+	return strings.TrimPrefix(id, "models/")
+}
+
+func (p *googleProvider) Classify(id string, known map[string]bool) Classification {
+	id = p.Canonicalize(id)
+	if known[id] {
+		return Known
+	}
+	suffix := lastToken(id)
+	if isAllDigits(suffix) && len(suffix) == 3 {
+		base := strings.TrimSuffix(id, "-"+suffix)
+		if known[base] {
+			return NumericRevision
+		}
+	}
+	return New
+}
@@ -0,0 +1,46 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("Meta", NewMeta)
+}
+
+// metaProvider treats a trailing "-instruct"/"-chat" suffix as an alias:
+// Meta publishes both a base Llama checkpoint and an instruction-tuned
+// variant under the same family name, and a new one under the tracked
+// base shouldn't be reported as a brand-new model.
+type metaProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewMeta builds the Meta Provider.
+func NewMeta(name string, cfg registry.Provider) Provider {
+	return &metaProvider{name: name, cfg: cfg}
+}
+
+func (p *metaProvider) Name() string { return p.name }
+
+func (p *metaProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *metaProvider) Canonicalize(id string) string { return id }
+
+func (p *metaProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	for _, suffix := range []string{"instruct", "chat"} {
+		if base, ok := strings.CutSuffix(strings.ToLower(id), "-"+suffix); ok && known[base] {
+			return Alias
+		}
+	}
+	return New
+}
@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("Mistral", NewMistral)
+}
+
+// mistralProvider classifies Mistral's YYMM revision tags (e.g.
+// "codestral-2508", "mistral-large-2512") as variants of the same base
+// model: a 4-digit suffix sharing every other token with a known ID.
+type mistralProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewMistral builds the Mistral Provider.
+func NewMistral(name string, cfg registry.Provider) Provider {
+	return &mistralProvider{name: name, cfg: cfg}
+}
+
+func (p *mistralProvider) Name() string { return p.name }
+
+func (p *mistralProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *mistralProvider) Canonicalize(id string) string { return id }
+
+func (p *mistralProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	base, ok := mistralBase(id)
+	if !ok {
+		return New
+	}
+	if known[base] {
+		return NumericRevision
+	}
+	return New
+}
+
+// mistralBase strips a trailing YYMM revision tag (exactly 4 digits) and
+// reports whether id had one.
+func mistralBase(id string) (string, bool) {
+	suffix := lastToken(id)
+	if !isAllDigits(suffix) || len(suffix) != 4 {
+		return "", false
+	}
+	return strings.TrimSuffix(id, "-"+suffix), true
+}
@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("OpenAI", NewOpenAI)
+}
+
+// openAIProvider classifies OpenAI's release-channel aliases ("-latest",
+// "-preview", "-chat-latest") and its date-stamped snapshots
+// ("-YYYYMMDD", e.g. "gpt-4.1-20250414") as variants of the un-suffixed
+// model rather than new ones.
+type openAIProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewOpenAI builds the OpenAI Provider.
+func NewOpenAI(name string, cfg registry.Provider) Provider {
+	return &openAIProvider{name: name, cfg: cfg}
+}
+
+func (p *openAIProvider) Name() string { return p.name }
+
+func (p *openAIProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *openAIProvider) Canonicalize(id string) string { return id }
+
+var openAIAliasSuffixes = []string{"latest", "beta", "preview", "chat-latest", "reasoning", "non-reasoning"}
+
+func (p *openAIProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	if isAllDigits(lastToken(id)) && len(lastToken(id)) == 8 {
+		base := strings.TrimSuffix(id, "-"+lastToken(id))
+		if known[base] {
+			return DateVariant
+		}
+	}
+	for _, suffix := range openAIAliasSuffixes {
+		if base, ok := strings.CutSuffix(id, "-"+suffix); ok && known[base] {
+			return Alias
+		}
+	}
+	return New
+}
+
+func lastToken(id string) string {
+	if i := strings.LastIndex(id, "-"); i >= 0 {
+		return id[i+1:]
+	}
+	return id
+}
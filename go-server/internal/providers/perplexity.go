@@ -0,0 +1,45 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("Perplexity", NewPerplexity)
+}
+
+// perplexityProvider treats a trailing "-online"/"-chat" suffix as an
+// alias of the base model, mirroring the web-search and chat variants
+// Perplexity ships alongside its plain sonar models.
+type perplexityProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewPerplexity builds the Perplexity Provider.
+func NewPerplexity(name string, cfg registry.Provider) Provider {
+	return &perplexityProvider{name: name, cfg: cfg}
+}
+
+func (p *perplexityProvider) Name() string { return p.name }
+
+func (p *perplexityProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *perplexityProvider) Canonicalize(id string) string { return id }
+
+func (p *perplexityProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	for _, suffix := range []string{"online", "chat"} {
+		if base, ok := strings.CutSuffix(id, "-"+suffix); ok && known[base] {
+			return Alias
+		}
+	}
+	return New
+}
@@ -0,0 +1,241 @@
+// Package providers gives each model-listing API its own naming-convention
+// logic instead of running one global set of date-stamp/alias/numeric-
+// revision heuristics over every provider's IDs. cmd/updater's diff
+// dispatcher looks a provider up by name and delegates to its Classify/
+// Canonicalize rather than applying one-size-fits-all rules; new providers
+// register themselves via Register so the dispatcher never needs editing.
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+
+	"go-server/internal/registry"
+)
+
+// Classification is the outcome of comparing one API-reported model ID
+// against a provider's set of tracked IDs.
+type Classification int
+
+const (
+	// New is a genuinely new model, unseen under any known ID.
+	New Classification = iota
+	// Known is an exact match of a tracked ID.
+	Known
+	// DateVariant is a known model re-listed under a calendar-date-stamped
+	// alias, e.g. Anthropic's "-20250929" snapshots.
+	DateVariant
+	// Alias is a known model re-listed under a release-channel suffix,
+	// e.g. OpenAI's "-latest"/"-preview".
+	Alias
+	// NumericRevision is a known model re-listed under a short numeric
+	// revision suffix, e.g. Mistral's YYMM tags or Google's "-001"/"-002".
+	NumericRevision
+	// Deprecated marks an ID a provider recognizes as a sunset variant
+	// that should never be reported as new, regardless of known.
+	Deprecated
+)
+
+func (c Classification) String() string {
+	switch c {
+	case New:
+		return "new"
+	case Known:
+		return "known"
+	case DateVariant:
+		return "date_variant"
+	case Alias:
+		return "alias"
+	case NumericRevision:
+		return "numeric_revision"
+	case Deprecated:
+		return "deprecated"
+	default:
+		return "unknown"
+	}
+}
+
+// IsVariant reports whether c represents some known model under a
+// different surface form, i.e. is not genuinely new.
+func (c Classification) IsVariant() bool {
+	return c != New
+}
+
+// Provider fetches and classifies one model-listing API's IDs using that
+// API's own naming conventions.
+type Provider interface {
+	Name() string
+	FetchIDs(ctx context.Context) ([]string, error)
+	Classify(id string, known map[string]bool) Classification
+	Canonicalize(id string) string
+}
+
+var (
+	mu  sync.RWMutex
+	reg = map[string]Provider{}
+)
+
+// Register adds p to the provider registry, keyed by p.Name(), so the diff
+// dispatcher can find it without a hardcoded provider list. Safe to call
+// more than once for the same name; the latest registration wins.
+func Register(p Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	reg[p.Name()] = p
+}
+
+// Get looks up a registered provider by name.
+func Get(name string) (Provider, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := reg[name]
+	return p, ok
+}
+
+// All returns every registered provider, sorted by name for determinism.
+func All() []Provider {
+	mu.RLock()
+	defer mu.RUnlock()
+	out := make([]Provider, 0, len(reg))
+	for _, p := range reg {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+	return out
+}
+
+// Build constructs and registers a Provider for every entry in reg, using
+// each name's bespoke implementation (see openai.go, anthropic.go, ...)
+// where one exists and falling back to a generic, ParseModelID-style
+// classifier (see generic.go) for any provider not yet given one — this
+// covers names like the ones in registry.Registry that don't have a
+// model-listing URL configured yet, without the dispatcher needing to
+// know about them in advance.
+func Build(reg *registry.Registry) map[string]Provider {
+	out := make(map[string]Provider, len(reg.Providers))
+	for _, name := range reg.ProviderOrder() {
+		cfg := reg.Providers[name]
+		ctor := constructors[name]
+		if ctor == nil {
+			ctor = NewGeneric
+		}
+		p := ctor(name, cfg)
+		Register(p)
+		out[name] = p
+	}
+	return out
+}
+
+// constructors maps a provider name to its bespoke implementation's
+// constructor; registered by each provider's init() in this package.
+var constructors = map[string]func(name string, cfg registry.Provider) Provider{}
+
+// registerConstructor is called from each provider file's init().
+func registerConstructor(name string, ctor func(name string, cfg registry.Provider) Provider) {
+	constructors[name] = ctor
+}
+
+// apiResponse is the common shape returned by OpenAI-compatible model list
+// APIs; mirrors cmd/updater's own apiResponse/apiModel, since FetchIDs here
+// needs to be self-contained (cmd/updater's fetch/retry/rate-limit
+// machinery isn't importable from this package).
+type apiResponse struct {
+	Data   []apiModel `json:"data"`
+	Models []apiModel `json:"models"` // Google uses top-level "models" array
+}
+
+type apiModel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"` // Google uses "name" (e.g. "models/gemini-2.5-pro")
+}
+
+// fetchGeneric queries an OpenAI-compatible (or Google-shaped) model
+// listing endpoint and returns the model IDs found, authenticating per
+// cfg.AuthHeader/AuthEnv the same way cmd/updater's fetchModels does.
+func fetchGeneric(ctx context.Context, name string, cfg registry.Provider) ([]string, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("providers: %s has no model-listing URL configured", name)
+	}
+
+	key := os.Getenv(cfg.AuthEnv)
+	if cfg.AuthEnv != "" && key == "" {
+		return nil, fmt.Errorf("providers: %s not set", cfg.AuthEnv)
+	}
+
+	url := cfg.URL
+	if cfg.AuthHeader == "" && key != "" {
+		url += "?key=" + key + "&pageSize=100"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AuthHeader != "" {
+		if cfg.AuthHeader == "x-api-key" {
+			req.Header.Set("x-api-key", key)
+			req.Header.Set("anthropic-version", "2023-06-01")
+		} else {
+			req.Header.Set(cfg.AuthHeader, "Bearer "+key)
+		}
+	}
+	for k, v := range cfg.ExtraHeaders {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("providers: %s returned HTTP %d", name, resp.StatusCode)
+	}
+
+	var parsed apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("providers: %s: decoding response: %w", name, err)
+	}
+
+	models := parsed.Data
+	if len(models) == 0 {
+		models = parsed.Models
+	}
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		id := m.ID
+		if id == "" {
+			id = m.Name
+		}
+		if cfg.IDPrefixStrip != "" {
+			id = stripPrefix(id, cfg.IDPrefixStrip)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func stripPrefix(id, prefix string) string {
+	if len(id) > len(prefix) && id[:len(prefix)] == prefix {
+		return id[len(prefix):]
+	}
+	return id
+}
+
+// isAllDigits reports whether s is non-empty and consists only of digits.
+func isAllDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
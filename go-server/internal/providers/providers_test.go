@@ -0,0 +1,122 @@
+package providers
+
+import (
+	"testing"
+
+	"go-server/internal/registry"
+)
+
+func boolSet(ids ...string) map[string]bool {
+	out := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		out[id] = true
+	}
+	return out
+}
+
+func TestBuild_RegistersBespokeAndGenericProviders(t *testing.T) {
+	reg := &registry.Registry{
+		Providers: map[string]registry.Provider{
+			"OpenAI":    {},
+			"Anthropic": {},
+			"Acme":      {}, // no bespoke implementation
+		},
+	}
+
+	built := Build(reg)
+	if len(built) != 3 {
+		t.Fatalf("expected 3 providers built, got %d", len(built))
+	}
+
+	if _, ok := built["OpenAI"].(*openAIProvider); !ok {
+		t.Errorf("expected OpenAI to use openAIProvider, got %T", built["OpenAI"])
+	}
+	if _, ok := built["Acme"].(*genericProvider); !ok {
+		t.Errorf("expected Acme to fall back to genericProvider, got %T", built["Acme"])
+	}
+
+	p, ok := Get("Anthropic")
+	if !ok || p.Name() != "Anthropic" {
+		t.Errorf("Get(%q) = %v, %v; want the registered Anthropic provider", "Anthropic", p, ok)
+	}
+
+	all := All()
+	if len(all) < 3 {
+		t.Errorf("All() returned %d providers, want at least 3", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Name() > all[i].Name() {
+			t.Errorf("All() not sorted by name: %q before %q", all[i-1].Name(), all[i].Name())
+		}
+	}
+}
+
+func TestOpenAIProvider_Classify(t *testing.T) {
+	p := NewOpenAI("OpenAI", registry.Provider{})
+	known := boolSet("gpt-4.1", "gpt-4o")
+
+	cases := map[string]Classification{
+		"gpt-4.1":             Known,
+		"gpt-4.1-20250414":    DateVariant,
+		"gpt-4o-latest":       Alias,
+		"gpt-4o-preview":      Alias,
+		"gpt-5-chat-latest":   New, // no known base "gpt-5"
+		"gpt-4-turbo-2024-04": New,
+	}
+	for id, want := range cases {
+		if got := p.Classify(id, known); got != want {
+			t.Errorf("Classify(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestAnthropicProvider_Classify(t *testing.T) {
+	p := NewAnthropic("Anthropic", registry.Provider{})
+	known := boolSet("claude-sonnet-4-5")
+
+	if got := p.Classify("claude-sonnet-4-5-20250929", known); got != DateVariant {
+		t.Errorf("Classify(date-stamped) = %v, want DateVariant", got)
+	}
+	if got := p.Classify("claude-opus-5", known); got != New {
+		t.Errorf("Classify(genuinely new) = %v, want New", got)
+	}
+}
+
+func TestGoogleProvider_Classify(t *testing.T) {
+	p := NewGoogle("Google", registry.Provider{})
+	known := boolSet("gemini-2.5-pro")
+
+	if got := p.Classify("models/gemini-2.5-pro", known); got != Known {
+		t.Errorf("Classify(prefixed known) = %v, want Known", got)
+	}
+	if got := p.Classify("gemini-2.5-pro-001", known); got != NumericRevision {
+		t.Errorf("Classify(-001 revision) = %v, want NumericRevision", got)
+	}
+	if got := p.Canonicalize("models/gemini-2.5-pro"); got != "gemini-2.5-pro" {
+		t.Errorf("Canonicalize = %q, want gemini-2.5-pro", got)
+	}
+}
+
+func TestMistralProvider_Classify(t *testing.T) {
+	p := NewMistral("Mistral", registry.Provider{})
+	known := boolSet("mistral-large")
+
+	if got := p.Classify("mistral-large-2512", known); got != NumericRevision {
+		t.Errorf("Classify(YYMM revision) = %v, want NumericRevision", got)
+	}
+	if got := p.Classify("mistral-small", known); got != New {
+		t.Errorf("Classify(different family) = %v, want New", got)
+	}
+}
+
+func TestGenericProvider_FallsBackToFamilyGrouping(t *testing.T) {
+	p := NewGeneric("Acme", registry.Provider{})
+	known := boolSet("acme-model")
+
+	if got := p.Classify("acme-model-20250601", known); got != DateVariant {
+		t.Errorf("Classify(date suffix) = %v, want DateVariant", got)
+	}
+	if got := p.Classify("acme-other-model", known); got != New {
+		t.Errorf("Classify(unrelated family) = %v, want New", got)
+	}
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"go-server/internal/registry"
+)
+
+func init() {
+	registerConstructor("xAI", NewXAI)
+}
+
+// xaiProvider treats a trailing "-beta"/"-latest" suffix as an alias of
+// the un-suffixed model; xAI doesn't date-stamp or YYMM-revision its
+// model IDs the way OpenAI/Anthropic/Mistral do; "fast"/"mini" are
+// distinct models (grok-4-fast vs grok-4), not revisions, so they aren't
+// treated as variants here.
+type xaiProvider struct {
+	name string
+	cfg  registry.Provider
+}
+
+// NewXAI builds the xAI Provider.
+func NewXAI(name string, cfg registry.Provider) Provider {
+	return &xaiProvider{name: name, cfg: cfg}
+}
+
+func (p *xaiProvider) Name() string { return p.name }
+
+func (p *xaiProvider) FetchIDs(ctx context.Context) ([]string, error) {
+	return fetchGeneric(ctx, p.name, p.cfg)
+}
+
+func (p *xaiProvider) Canonicalize(id string) string { return id }
+
+func (p *xaiProvider) Classify(id string, known map[string]bool) Classification {
+	if known[id] {
+		return Known
+	}
+	for _, suffix := range []string{"beta", "latest"} {
+		if base, ok := strings.CutSuffix(id, "-"+suffix); ok && known[base] {
+			return Alias
+		}
+	}
+	return New
+}
@@ -0,0 +1,207 @@
+// Package registry loads the updater's provider and tracked-model
+// configuration from a canonical file, so adding a provider or a model
+// doesn't require editing Go source and rebuilding. Both YAML and JSON
+// are accepted: YAML is decoded into a generic value and re-marshaled to
+// JSON before unmarshaling into the typed structs below, so one set of
+// `json` tags describes both formats.
+package registry
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed default_registry.yaml
+var defaultRegistryYAML []byte
+
+// ModelEntry is a single tracked model under a Provider.
+type ModelEntry struct {
+	ID           string `yaml:"id" json:"id"`
+	Status       string `yaml:"status,omitempty" json:"status,omitempty"` // "current", "legacy", or "deprecated"
+	DeprecatedOn string `yaml:"deprecated_on,omitempty" json:"deprecated_on,omitempty"`
+	Replacement  string `yaml:"replacement,omitempty" json:"replacement,omitempty"`
+
+	// Family and Size group variants of the same model the way
+	// ParseModelID does (cmd/updater/modelid.go); Modality and
+	// ContextWindow are display metadata for the cheatsheet. All are
+	// optional: a bare {id, status} entry is still a valid model.
+	Family        string   `yaml:"family,omitempty" json:"family,omitempty"`
+	Size          string   `yaml:"size,omitempty" json:"size,omitempty"`
+	Modality      string   `yaml:"modality,omitempty" json:"modality,omitempty"`
+	ContextWindow int      `yaml:"context_window,omitempty" json:"context_window,omitempty"`
+	Aliases       []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+}
+
+// Provider describes how to query a provider's model-listing API and which
+// models under it are tracked.
+type Provider struct {
+	URL           string            `yaml:"url,omitempty" json:"url,omitempty"`
+	AuthEnv       string            `yaml:"auth_env,omitempty" json:"auth_env,omitempty"`
+	AuthHeader    string            `yaml:"auth_header,omitempty" json:"auth_header,omitempty"` // empty means query-param auth (Google)
+	Paginate      bool              `yaml:"paginate,omitempty" json:"paginate,omitempty"`
+	IDPrefixStrip string            `yaml:"id_prefix_strip,omitempty" json:"id_prefix_strip,omitempty"`
+	ExtraHeaders  map[string]string `yaml:"extra_headers,omitempty" json:"extra_headers,omitempty"`
+	Models        []ModelEntry      `yaml:"models" json:"models"`
+
+	// NamingRules is reserved for per-provider ID normalization rules
+	// (e.g. stripping a vendor prefix before comparing IDs); it's passed
+	// through as-is today, with no built-in interpretation.
+	NamingRules map[string]string `yaml:"naming_rules,omitempty" json:"naming_rules,omitempty"`
+
+	// Schedule controls how often --serve (daemon mode) checks this
+	// provider: a bare Go duration, "@every <duration>", or a 5-field
+	// cron expression. Empty means the daemon's default interval.
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	// RateLimitPerMinute caps how often the daemon may call this
+	// provider's API. Zero means the daemon's default rate.
+	RateLimitPerMinute float64 `yaml:"rate_limit_per_minute,omitempty" json:"rate_limit_per_minute,omitempty"`
+}
+
+// NotifierConfig configures one notification sink (Slack, Discord, a plain
+// webhook, or SMTP email) that change reports are fanned out to. Fields
+// irrelevant to Kind are left zero; see internal/notify for how each kind
+// interprets them.
+type NotifierConfig struct {
+	Kind string `yaml:"kind" json:"kind"` // "slack", "discord", "webhook", or "email"
+
+	WebhookURL string `yaml:"webhook_url,omitempty" json:"webhook_url,omitempty"` // slack, discord
+	URL        string `yaml:"url,omitempty" json:"url,omitempty"`                 // webhook
+
+	SMTPHost    string   `yaml:"smtp_host,omitempty" json:"smtp_host,omitempty"`
+	SMTPPort    int      `yaml:"smtp_port,omitempty" json:"smtp_port,omitempty"`
+	SMTPUser    string   `yaml:"smtp_user,omitempty" json:"smtp_user,omitempty"`
+	SMTPPassEnv string   `yaml:"smtp_pass_env,omitempty" json:"smtp_pass_env,omitempty"`
+	From        string   `yaml:"from,omitempty" json:"from,omitempty"`
+	To          []string `yaml:"to,omitempty" json:"to,omitempty"`
+
+	// Filters: a report is sent to this sink only if it matches. OnNew/
+	// OnMissing/OnError gate on what kind of change the report carries;
+	// Providers restricts to a provider allow-list (empty means all).
+	OnNew     bool     `yaml:"on_new,omitempty" json:"on_new,omitempty"`
+	OnMissing bool     `yaml:"on_missing,omitempty" json:"on_missing,omitempty"`
+	OnError   bool     `yaml:"on_error,omitempty" json:"on_error,omitempty"`
+	Providers []string `yaml:"providers,omitempty" json:"providers,omitempty"`
+
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty" json:"timeout_seconds,omitempty"`
+	Retries        int `yaml:"retries,omitempty" json:"retries,omitempty"`
+}
+
+// Registry is the root document shape of registry.yaml/registry.json.
+type Registry struct {
+	Providers map[string]Provider `yaml:"providers" json:"providers"`
+	Notifiers []NotifierConfig    `yaml:"notifiers,omitempty" json:"notifiers,omitempty"`
+}
+
+// Load reads and parses a registry file at path, as JSON if its extension
+// is ".json" and as YAML otherwise.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("registry: read %s: %w", path, err)
+	}
+	reg, err := parse(data, path)
+	if err != nil {
+		return nil, fmt.Errorf("registry: parse %s: %w", path, err)
+	}
+	return reg, nil
+}
+
+// LoadURL fetches a registry document over HTTP(S), for operators who host
+// their registry somewhere other than a local file (--registry-url).
+func LoadURL(url string) (*Registry, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("registry: fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry: fetch %s: HTTP %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("registry: read %s: %w", url, err)
+	}
+	reg, err := parse(data, url)
+	if err != nil {
+		return nil, fmt.Errorf("registry: parse %s: %w", url, err)
+	}
+	return reg, nil
+}
+
+// Default returns the registry embedded into the binary at build time
+// (internal/registry/default_registry.yaml). cmd/updater falls back to it
+// when loading its configured registry (--config/REGISTRY_CONFIG or
+// --registry-url/REGISTRY_URL) fails, and cmd/genmodels generates
+// internal/models/data.go from it unless given -registry — so a fresh
+// checkout with no external registry file still works.
+func Default() (*Registry, error) {
+	reg, err := parse(defaultRegistryYAML, "default_registry.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("registry: parse embedded default: %w", err)
+	}
+	return reg, nil
+}
+
+// parse decodes data as JSON if name ends in ".json", otherwise as YAML.
+// The YAML path decodes into a generic value and re-marshals it to JSON
+// before unmarshaling into Registry, so a single set of `json` struct
+// tags describes both input formats.
+func parse(data []byte, name string) (*Registry, error) {
+	if strings.HasSuffix(strings.ToLower(name), ".json") {
+		var reg Registry
+		if err := json.Unmarshal(data, &reg); err != nil {
+			return nil, err
+		}
+		return &reg, nil
+	}
+
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	asJSON, err := json.Marshal(generic)
+	if err != nil {
+		return nil, err
+	}
+	var reg Registry
+	if err := json.Unmarshal(asJSON, &reg); err != nil {
+		return nil, err
+	}
+	return &reg, nil
+}
+
+// KnownModels derives the updater's provider -> {model ID -> tracked} map
+// from the registry.
+func (r *Registry) KnownModels() map[string]map[string]bool {
+	known := make(map[string]map[string]bool, len(r.Providers))
+	for name, p := range r.Providers {
+		ids := make(map[string]bool, len(p.Models))
+		for _, m := range p.Models {
+			ids[m.ID] = true
+		}
+		known[name] = ids
+	}
+	return known
+}
+
+// ProviderOrder returns provider names sorted alphabetically, for
+// determinism since map iteration order isn't stable; the order the
+// providers appear in the registry file is not kept.
+func (r *Registry) ProviderOrder() []string {
+	names := make([]string, 0, len(r.Providers))
+	for name := range r.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
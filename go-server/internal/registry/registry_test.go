@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempRegistry(t *testing.T, yaml string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registry.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatalf("writing temp registry: %v", err)
+	}
+	return path
+}
+
+func TestLoad_ParsesProvidersAndModels(t *testing.T) {
+	path := writeTempRegistry(t, `
+providers:
+  OpenAI:
+    url: "https://api.openai.com/v1/models"
+    auth_env: "OPENAI_API_KEY"
+    auth_header: "Authorization"
+    models:
+      - id: "gpt-5"
+        status: current
+      - id: "gpt-4o"
+        status: current
+`)
+	reg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(reg.Providers) != 1 {
+		t.Fatalf("expected 1 provider, got %d", len(reg.Providers))
+	}
+	p := reg.Providers["OpenAI"]
+	if p.URL != "https://api.openai.com/v1/models" || p.AuthEnv != "OPENAI_API_KEY" {
+		t.Errorf("unexpected provider config: %+v", p)
+	}
+	if len(p.Models) != 2 {
+		t.Errorf("expected 2 models, got %d", len(p.Models))
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestLoad_ParsesJSONByExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registry.json")
+	body := `{"providers": {"OpenAI": {"url": "https://api.openai.com/v1/models",
+		"models": [{"id": "gpt-5", "status": "current", "family": "gpt-5"}]}}}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("writing temp registry: %v", err)
+	}
+	reg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	p, ok := reg.Providers["OpenAI"]
+	if !ok || len(p.Models) != 1 || p.Models[0].ID != "gpt-5" || p.Models[0].Family != "gpt-5" {
+		t.Errorf("unexpected provider config: %+v", p)
+	}
+}
+
+func TestDefault_LoadsEmbeddedRegistry(t *testing.T) {
+	reg, err := Default()
+	if err != nil {
+		t.Fatalf("Default: %v", err)
+	}
+	if len(reg.Providers) == 0 {
+		t.Fatal("expected the embedded default registry to have providers")
+	}
+	p, ok := reg.Providers["OpenAI"]
+	if !ok || len(p.Models) == 0 {
+		t.Error("expected the embedded default registry to track OpenAI models")
+	}
+}
+
+func TestRegistry_KnownModels(t *testing.T) {
+	reg := &Registry{Providers: map[string]Provider{
+		"OpenAI": {Models: []ModelEntry{{ID: "gpt-5"}, {ID: "gpt-4o"}}},
+	}}
+	known := reg.KnownModels()
+	if !known["OpenAI"]["gpt-5"] || !known["OpenAI"]["gpt-4o"] {
+		t.Errorf("expected both model IDs to be tracked, got %v", known)
+	}
+}
+
+func TestRegistry_ProviderOrderIsSorted(t *testing.T) {
+	reg := &Registry{Providers: map[string]Provider{
+		"Zhipu":  {},
+		"AI21":   {},
+		"OpenAI": {},
+	}}
+	order := reg.ProviderOrder()
+	want := []string{"AI21", "OpenAI", "Zhipu"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, order)
+			break
+		}
+	}
+}